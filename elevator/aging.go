@@ -0,0 +1,75 @@
+package elevator
+
+import "sort"
+
+// SetOrderAging raises the dispatch priority of an order by one level every agingTicks it waits in the buffer.
+// An order waiting more than maxWaitTicks is overdue: it is dispatched first, may take an elevator moving empty
+// to a pickup which is not more urgent and is counted in the metrics. A zero value disables each rule.
+func (c *Controller) SetOrderAging(agingTicks int, maxWaitTicks int) {
+	c.agingTicks = agingTicks
+	c.maxWaitTicks = maxWaitTicks
+}
+
+// waitedTicks counts the ticks since the order was pushed, orders which were not pushed to the controller never wait
+func (c *Controller) waitedTicks(order Order) int {
+	trip, ok := c.trips[order.id]
+	if !ok {
+		return 0
+	}
+	return c.tick - trip.PushTick
+}
+
+func (c *Controller) isOverdue(order Order) bool {
+	return c.maxWaitTicks > 0 && c.waitedTicks(order) > c.maxWaitTicks
+}
+
+// dispatchPriority is the priority of the order raised by its age, capped at the medical emergency priority
+func (c *Controller) dispatchPriority(order Order) Priority {
+	if c.agingTicks <= 0 {
+		return order.priority
+	}
+	priority := order.priority + Priority(c.waitedTicks(order)/c.agingTicks)
+	if priority > MedicalEmergencyPriority {
+		return MedicalEmergencyPriority
+	}
+	return priority
+}
+
+// canPreempt tells if the order can take the elevator moving empty to the pickup of another order
+func (c *Controller) canPreempt(e Elevator, order Order) bool {
+	if e.canBePreemptedBy(order) {
+		return true
+	}
+	// an overdue order is forced on the elevator, unless the elevator is already serving an overdue order or a more
+	// urgent one, which would take the elevator back on the next tick
+	return e.isMovingEmptyToPickup() && c.isOverdue(order) && !c.isOverdue(e.currentOrder) && e.currentOrder.priority <= order.priority
+}
+
+// ageOrdersBuffer flags the orders that exceeded the maximum wait and sorts the buffer by dispatch priority,
+// overdue orders first and the oldest first within the same priority
+func (c *Controller) ageOrdersBuffer() {
+	if c.agingTicks <= 0 && c.maxWaitTicks <= 0 {
+		return
+	}
+
+	for _, order := range c.ordersBuffer {
+		if c.isOverdue(order) && !c.trips[order.id].Overdue {
+			c.metrics.OverdueOrders++
+			c.updateTrip(order, func(trip *Trip) {
+				trip.Overdue = true
+			})
+			c.log().Warn("overdue", "tick", c.tick, "order", order.String(), "orderId", order.id, "waitedTicks", c.waitedTicks(order))
+		}
+	}
+
+	sort.SliceStable(c.ordersBuffer, func(i, j int) bool {
+		left, right := c.ordersBuffer[i], c.ordersBuffer[j]
+		if c.isOverdue(left) != c.isOverdue(right) {
+			return c.isOverdue(left)
+		}
+		if c.dispatchPriority(left) != c.dispatchPriority(right) {
+			return c.dispatchPriority(left) > c.dispatchPriority(right)
+		}
+		return c.waitedTicks(left) > c.waitedTicks(right)
+	})
+}
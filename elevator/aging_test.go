@@ -0,0 +1,158 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestController_dispatchPriority(t *testing.T) {
+	tests := []struct {
+		name       string
+		agingTicks int
+		priority   Priority
+		tick       int
+		want       Priority
+	}{
+		{name: "no-aging", agingTicks: 0, priority: NormalPriority, tick: 100, want: NormalPriority},
+		{name: "young-order", agingTicks: 5, priority: NormalPriority, tick: 4, want: NormalPriority},
+		{name: "one-level", agingTicks: 5, priority: NormalPriority, tick: 5, want: VIPPriority},
+		{name: "two-levels", agingTicks: 5, priority: NormalPriority, tick: 12, want: MedicalEmergencyPriority},
+		{name: "capped", agingTicks: 5, priority: VIPPriority, tick: 50, want: MedicalEmergencyPriority},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.SetOrderAging(tt.agingTicks, 0)
+			controller.PushPriorityOrder(0, 5, tt.priority)
+			controller.tick = tt.tick
+
+			if got := controller.dispatchPriority(controller.ordersBuffer[0]); got != tt.want {
+				t.Errorf("dispatchPriority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_ageOrdersBuffer(t *testing.T) {
+	tests := []struct {
+		name         string
+		agingTicks   int
+		maxWaitTicks int
+		tick         int
+		want         []int
+		wantOverdue  int
+	}{
+		{name: "disabled", tick: 20, want: []int{2, 1, 3}},
+		{name: "vip-still-first", agingTicks: 10, tick: 9, want: []int{2, 1, 3}},
+		{name: "old-order-catches-up", agingTicks: 10, tick: 10, want: []int{1, 2, 3}},
+		{name: "overdue-first", maxWaitTicks: 5, tick: 7, want: []int{1, 3, 2}, wantOverdue: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.SetOrderAging(tt.agingTicks, tt.maxWaitTicks)
+			controller.PushOrder(0, 5)
+			controller.tick = 2
+			controller.PushPriorityOrder(7, 2, VIPPriority)
+			controller.tick = 1
+			controller.PushOrder(4, 1)
+			controller.tick = tt.tick
+
+			controller.ageOrdersBuffer()
+			controller.ageOrdersBuffer()
+
+			got := []int{}
+			for _, order := range controller.ordersBuffer {
+				got = append(got, order.id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ageOrdersBuffer() = %v, want %v", got, tt.want)
+			}
+			if controller.metrics.OverdueOrders != tt.wantOverdue {
+				t.Errorf("OverdueOrders = %d, want %d", controller.metrics.OverdueOrders, tt.wantOverdue)
+			}
+		})
+	}
+}
+
+func TestController_canPreempt(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.SetOrderAging(0, 5)
+	controller.PushOrder(0, 5)
+	controller.tick = 6
+	controller.PushOrder(8, 1)
+	overdueOrder, recentOrder := controller.ordersBuffer[0], controller.ordersBuffer[1]
+
+	movingToRecent := Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(8)}, currentOrder: recentOrder, motion: DefaultMotion}
+	movingToOverdue := Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(0)}, currentOrder: overdueOrder, motion: DefaultMotion}
+	transporting := Elevator{index: 1, position: 3, state: TransportingPeopleTo{Floor(1)}, currentOrder: recentOrder, motion: DefaultMotion}
+
+	if !controller.canPreempt(movingToRecent, overdueOrder) {
+		t.Errorf("an overdue order should take an elevator moving empty")
+	}
+	if controller.canPreempt(movingToOverdue, recentOrder) {
+		t.Errorf("a recent order should not take an elevator moving empty to an overdue order")
+	}
+	if controller.canPreempt(transporting, overdueOrder) {
+		t.Errorf("an overdue order should not take an elevator with people on board")
+	}
+	medicalOrder := Order{from: 8, to: 1, priority: MedicalEmergencyPriority, id: 3}
+	movingToMedical := Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(8)}, currentOrder: medicalOrder, motion: DefaultMotion}
+	if controller.canPreempt(movingToMedical, overdueOrder) {
+		t.Errorf("an overdue order should not take an elevator moving empty to a more urgent order")
+	}
+}
+
+func TestController_step_overdueOrderAndMedicalPickup(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.SetOrderAging(0, 2)
+	controller.PushOrder(0, 5)
+	controller.tick = 5
+	controller.PushPriorityOrder(9, 0, MedicalEmergencyPriority)
+	normalOrder, medicalOrder := controller.ordersBuffer[1], controller.ordersBuffer[0]
+	controller.elevators[1] = Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(9)}, currentOrder: medicalOrder, motion: DefaultMotion, energyModel: DefaultEnergyModel}
+	controller.ordersBuffer = Orders{normalOrder}
+	controller.tripAssigned(medicalOrder, 1)
+
+	for i := 0; i < 3; i++ {
+		controller.step()
+	}
+
+	// the overdue order would take the elevator, then the medical emergency would take it back, tick after tick
+	if got := controller.elevators[1].currentOrder; got != medicalOrder {
+		t.Errorf("currentOrder = %v, want the medical emergency %v", got, medicalOrder)
+	}
+	if got := controller.Trips()[1].Reassignments; got != 0 {
+		t.Errorf("the medical emergency was reassigned %d time(s), want 0", got)
+	}
+}
+
+func TestController_SetOrderAging(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.SetOrderAging(0, 3)
+	controller.PushOrder(0, 9)
+	controller.PushOrder(9, 0)
+	controller.PushOrder(2, 3)
+
+	for !controller.isOver() {
+		controller.step()
+	}
+
+	overdue := []int{}
+	for _, trip := range controller.Trips() {
+		if !trip.Delivered() {
+			t.Errorf("trip %d should be delivered", trip.ID)
+		}
+		if trip.Overdue {
+			overdue = append(overdue, trip.ID)
+		}
+	}
+	if !reflect.DeepEqual(overdue, []int{2, 3}) || controller.Metrics().OverdueOrders != 2 {
+		t.Errorf("overdue trips = %v, metrics = %s", overdue, controller.Metrics())
+	}
+}
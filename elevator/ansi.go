@@ -0,0 +1,151 @@
+package elevator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	ansiReset = "\033[0m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiBlue  = "\033[34m"
+	ansiGrey  = "\033[90m"
+)
+
+func stateColour(e ElevatorSnapshot) string {
+	if e.Fault != "" {
+		return ansiRed
+	}
+	switch e.State {
+	case "LoadingAtFloor":
+		return ansiGreen
+	case "MovingEmptyTo", "RepositioningTo":
+		return ansiGrey
+	case "TransportingPeopleTo":
+		return ansiBlue
+	case "RecallingTo":
+		return ansiRed
+	default:
+		return ""
+	}
+}
+
+func colourize(text string, colour string) string {
+	if colour == "" {
+		return text
+	}
+	return colour + text + ansiReset
+}
+
+// withColours gives the colour version of the renderer, renderers without colours are kept as they are
+func withColours(renderer Renderer) Renderer {
+	switch renderer.(type) {
+	case nil, LineRenderer:
+		return LineRenderer{Colours: true}
+	case VerticalRenderer:
+		return VerticalRenderer{Colours: true}
+	default:
+		return renderer
+	}
+}
+
+// IsTerminal tells if the file is a terminal and not a pipe or a regular file
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalWidth asks stty for the number of columns of the terminal, 0 when it is unknown
+func terminalWidth(file *os.File) int {
+	cmd := exec.Command("stty", "size")
+	cmd.Stdin = file
+	output, err := cmd.Output()
+	if err != nil {
+		return 0
+	}
+	var rows, columns int
+	if _, err := fmt.Sscan(string(output), &rows, &columns); err != nil {
+		return 0
+	}
+	return columns
+}
+
+// displayWidth counts the columns of a line in the terminal: colour codes take no room and tabs go to the next multiple of 8
+func displayWidth(line string) int {
+	width := 0
+	inEscape := false
+	for i, r := range line {
+		switch {
+		case r == '\033':
+			inEscape = true
+		case inEscape:
+			// the sequence ends with its final byte, after the opening bracket
+			if line[i-1] != '\033' && r >= '@' && r <= '~' {
+				inEscape = false
+			}
+		case r == '\t':
+			width = (width/8 + 1) * 8
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// AnsiScreen redraws each frame over the previous one instead of scrolling the terminal.
+// A line longer than the width of the terminal wraps on several rows, without width every line is one row
+type AnsiScreen struct {
+	out        io.Writer
+	width      int
+	drawnLines int
+}
+
+// rows counts the rows of the terminal taken by the frame
+func (s *AnsiScreen) rows(frame string) int {
+	rows := 0
+	for _, line := range strings.Split(strings.TrimSuffix(frame, "\n"), "\n") {
+		rows++
+		if width := displayWidth(line); s.width > 0 && width > s.width {
+			rows += (width - 1) / s.width
+		}
+	}
+	return rows
+}
+
+func (s *AnsiScreen) draw(frame string) {
+	if s.drawnLines > 0 {
+		// move up to the first line of the previous frame then clear down to the end of the screen
+		fmt.Fprintf(s.out, "\033[%dA\r\033[J", s.drawnLines)
+	}
+	if !strings.HasSuffix(frame, "\n") {
+		frame += "\n"
+	}
+	fmt.Fprint(s.out, frame)
+	s.drawnLines = s.rows(frame)
+}
+
+// EnableAnsiScreen redraws the elevators in place with colours when out is a terminal, the width of the terminal
+// is read once to count the wrapped lines. Otherwise the simulation keeps printing one display after the other
+func (c *Controller) EnableAnsiScreen(out *os.File) bool {
+	if !IsTerminal(out) {
+		return false
+	}
+	c.screen = &AnsiScreen{out: out, width: terminalWidth(out)}
+	c.renderer = withColours(c.renderer)
+	return true
+}
+
+func (c *Controller) statusLine() string {
+	status := fmt.Sprintf("\ttick %d | %d order(s) in queue", c.tick, len(c.ordersBuffer))
+	if c.mode == FireRecall {
+		status += " | FIRE RECALL"
+	}
+	return status
+}
@@ -0,0 +1,125 @@
+package elevator
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStateColour(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator ElevatorSnapshot
+		want     string
+	}{
+		{name: "loading", elevator: ElevatorSnapshot{State: "LoadingAtFloor"}, want: ansiGreen},
+		{name: "moving-empty", elevator: ElevatorSnapshot{State: "MovingEmptyTo"}, want: ansiGrey},
+		{name: "repositioning", elevator: ElevatorSnapshot{State: "RepositioningTo"}, want: ansiGrey},
+		{name: "transporting", elevator: ElevatorSnapshot{State: "TransportingPeopleTo"}, want: ansiBlue},
+		{name: "faulty", elevator: ElevatorSnapshot{State: "TransportingPeopleTo", Fault: "BROKEN DOWN"}, want: ansiRed},
+		{name: "stopped", elevator: ElevatorSnapshot{State: "StopAtFloor"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stateColour(tt.elevator); got != tt.want {
+				t.Errorf("stateColour() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineRenderer_elevatorLine_colours(t *testing.T) {
+	elevator := Elevator{index: 1, currentOrder: Order{from: Floor(1), to: Floor(3)}, position: 1, state: LoadingAtFloor{Floor(1)}}
+
+	want := ansiGreen + "1 [1->3](LoadingAtFloor)      : _ ↑1↑ _ ❲3❳" + ansiReset
+	if got := (LineRenderer{Colours: true}).elevatorLine(elevator.snapshot()); got != want {
+		t.Errorf("elevatorLine() = %q, want %q", got, want)
+	}
+}
+
+func TestAnsiScreen_draw(t *testing.T) {
+	out := &bytes.Buffer{}
+	screen := AnsiScreen{out: out}
+
+	screen.draw("first\nframe\n")
+	screen.draw("second\nframe\nstatus")
+
+	want := "first\nframe\n" + "\033[2A\r\033[J" + "second\nframe\nstatus\n"
+	if got := out.String(); got != want {
+		t.Errorf("draw() = %q, want %q", got, want)
+	}
+	if screen.drawnLines != 3 {
+		t.Errorf("drawnLines = %d, want 3", screen.drawnLines)
+	}
+}
+
+func TestAnsiScreen_draw_wrappedLines(t *testing.T) {
+	out := &bytes.Buffer{}
+	screen := AnsiScreen{out: out, width: 10}
+
+	// a tab then 12 characters take 20 columns, that is 2 rows of a 10 columns terminal
+	screen.draw("\tabcdefghijkl\n" + colourize("0123456789", ansiBlue) + "\n")
+	screen.draw("next\n")
+
+	want := "\tabcdefghijkl\n" + colourize("0123456789", ansiBlue) + "\n" + "\033[3A\r\033[J" + "next\n"
+	if got := out.String(); got != want {
+		t.Errorf("draw() = %q, want %q", got, want)
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want int
+	}{
+		{name: "plain", line: "1 [1->3]", want: 8},
+		{name: "tab", line: "\t1 ", want: 10},
+		{name: "colours", line: colourize("|☺⟩ _", ansiGreen), want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := displayWidth(tt.line); got != tt.want {
+				t.Errorf("displayWidth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_EnableAnsiScreen_notTerminal(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	controller := NewController(0)
+	controller.SetRenderer(VerticalRenderer{})
+	if controller.EnableAnsiScreen(file) {
+		t.Errorf("EnableAnsiScreen() = true for a regular file")
+	}
+	if controller.screen != nil || controller.renderer != (VerticalRenderer{}) {
+		t.Errorf("EnableAnsiScreen() changed the display of a regular file: %v, %v", controller.screen, controller.renderer)
+	}
+}
+
+func TestWithColours(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		want     Renderer
+	}{
+		{name: "default", renderer: nil, want: LineRenderer{Colours: true}},
+		{name: "line", renderer: LineRenderer{}, want: LineRenderer{Colours: true}},
+		{name: "vertical", renderer: VerticalRenderer{}, want: VerticalRenderer{Colours: true}},
+		{name: "json", renderer: JSONRenderer{}, want: JSONRenderer{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withColours(tt.renderer); got != tt.want {
+				t.Errorf("withColours() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package elevator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Building is the range of floors served by a group of elevators, from the ground floor up
+type Building struct {
+	MinFloor int `json:"minFloor"`
+	MaxFloor int `json:"maxFloor"`
+}
+
+var DefaultBuilding = Building{MinFloor: minFloor, MaxFloor: maxFloor}
+
+func (b Building) validate() error {
+	if b.MinFloor < minFloor || b.MinFloor > b.MaxFloor {
+		return fmt.Errorf("building floors [%d-%d] should start at floor %d or above and go up", b.MinFloor, b.MaxFloor, minFloor)
+	}
+	return nil
+}
+
+func (b Building) contains(floor Floor) bool {
+	return floor.toInt() >= b.MinFloor && floor.toInt() <= b.MaxFloor
+}
+
+// SetBuilding restricts the controller to the floors of the building, elevators start at its lowest floor
+func (c *Controller) SetBuilding(building Building) error {
+	if len(c.elevators) > 0 {
+		return errors.New("the building must be set before adding elevators")
+	}
+	if err := building.validate(); err != nil {
+		return err
+	}
+	c.building = &building
+	return nil
+}
+
+func (c *Controller) Building() Building {
+	if c.building == nil {
+		return DefaultBuilding
+	}
+	return *c.building
+}
@@ -0,0 +1,89 @@
+package elevator
+
+import (
+	"testing"
+)
+
+func TestController_SetBuilding(t *testing.T) {
+	tests := []struct {
+		name      string
+		building  Building
+		elevators int
+		wantErr   bool
+	}{
+		{name: "default", building: DefaultBuilding},
+		{name: "upper-floors", building: Building{MinFloor: 3, MaxFloor: 6}},
+		{name: "single-floor", building: Building{MinFloor: 2, MaxFloor: 2}},
+		{name: "tall", building: Building{MinFloor: 0, MaxFloor: 30}},
+		{name: "underground", building: Building{MinFloor: -2, MaxFloor: 6}, wantErr: true},
+		{name: "reversed", building: Building{MinFloor: 5, MaxFloor: 1}, wantErr: true},
+		{name: "after-elevators", building: Building{MinFloor: 3, MaxFloor: 6}, elevators: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			for index := 1; index <= tt.elevators; index++ {
+				controller.AddElevator(index)
+			}
+
+			err := controller.SetBuilding(tt.building)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetBuilding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && controller.Building() != tt.building {
+				t.Errorf("Building() = %v, want %v", controller.Building(), tt.building)
+			}
+		})
+	}
+}
+
+func TestController_Building_orders(t *testing.T) {
+	controller := NewController(0)
+	controller.SetBuilding(Building{MinFloor: 3, MaxFloor: 6})
+	controller.AddElevator(1)
+
+	if got := controller.elevators[1].position; got != Floor(3) {
+		t.Errorf("the elevator should start at the lowest floor of the building, got floor %d", got)
+	}
+	if err := controller.PushOrder(1, 5); err == nil {
+		t.Errorf("an order below the building should be rejected")
+	}
+	if err := controller.PushOrder(4, 6); err != nil {
+		t.Errorf("PushOrder() unexpected error = %v", err)
+	}
+	if len(controller.rejectedOrders) != 1 || len(controller.ordersBuffer) != 1 {
+		t.Errorf("rejectedOrders = %v, ordersBuffer = %v", controller.rejectedOrders, controller.ordersBuffer)
+	}
+	if snapshot := controller.Snapshot(); snapshot.MinFloor != 3 || snapshot.MaxFloor != 6 {
+		t.Errorf("the snapshot should show floors [3-6], got [%d-%d]", snapshot.MinFloor, snapshot.MaxFloor)
+	}
+}
+
+func TestReturnToLobby_parkingFloors_building(t *testing.T) {
+	controller := NewController(0)
+	controller.SetBuilding(Building{MinFloor: 2, MaxFloor: 8})
+
+	if got := (ReturnToLobby{Lobby: 0}).parkingFloors(controller, 1); len(got) != 1 || got[0] != Floor(2) {
+		t.Errorf("parkingFloors() = %v, want the lowest floor of the building", got)
+	}
+}
+
+func TestController_Building_tall(t *testing.T) {
+	controller := NewController(0)
+	controller.SetBuilding(Building{MinFloor: 0, MaxFloor: 30})
+	controller.AddElevator(1)
+	controller.EnableInvariantChecks()
+	if err := controller.PushOrder(2, 25); err != nil {
+		t.Fatalf("PushOrder() unexpected error = %v", err)
+	}
+
+	controller.Record(100)
+
+	if trips := controller.Trips(); len(trips) != 1 || !trips[0].Delivered() {
+		t.Errorf("Trips() = %+v, want the order delivered at floor 25", trips)
+	}
+	if violations := controller.Violations(); len(violations) > 0 {
+		t.Errorf("Violations() = %v", violations)
+	}
+}
@@ -0,0 +1,67 @@
+package elevator
+
+import "fmt"
+
+// CancelOrder drops an order waiting in the buffer, or releases the elevator moving empty to its pickup.
+// Once people boarded, the order can no longer be cancelled
+func (c *Controller) CancelOrder(id int) error {
+	for orderIndex, order := range c.ordersBuffer {
+		if order.id == id {
+			c.ordersBuffer = removeOrder(c.ordersBuffer, orderIndex)
+			c.orderCancelled(order, notYet)
+			return nil
+		}
+	}
+
+	for _, index := range c.sortedIndexes() {
+		elevator := c.elevators[index]
+		for _, rider := range elevator.riders {
+			if rider.id == id {
+				return fmt.Errorf("order %s n°%d can not be cancelled, people boarded elevator n°%d", rider, id, index)
+			}
+		}
+		if elevator.currentOrder.id != id || id == 0 {
+			continue
+		}
+		newElevator, releasedOrder := elevator.releaseOrder()
+		if releasedOrder.id != id {
+			return fmt.Errorf("order %s n°%d can not be cancelled, people boarded elevator n°%d", elevator.currentOrder, id, index)
+		}
+		c.elevators[index] = newElevator
+		c.orderCancelled(releasedOrder, index)
+		return nil
+	}
+
+	return fmt.Errorf("order n°%d can not be cancelled, it is neither waiting nor assigned", id)
+}
+
+func (c *Controller) orderCancelled(order Order, index int) {
+	c.metrics.CancelledOrders++
+	c.updateTrip(order, func(trip *Trip) {
+		trip.Cancelled = true
+	})
+	c.log().Info("cancel", "tick", c.tick, "order", order.String(), "orderId", order.id, "elevator", index)
+}
+
+type scheduledCancellation struct {
+	tick int
+	id   int
+}
+
+// ScheduleCancellation cancels the order with the given id when the simulation reaches the given tick
+func (c *Controller) ScheduleCancellation(tick int, id int) {
+	c.scheduledCancellations = append(c.scheduledCancellations, scheduledCancellation{tick: tick, id: id})
+}
+
+func (c *Controller) applyScheduledCancellations() {
+	remainingCancellations := []scheduledCancellation{}
+	for _, scheduled := range c.scheduledCancellations {
+		if scheduled.tick > c.tick {
+			remainingCancellations = append(remainingCancellations, scheduled)
+		} else if err := c.CancelOrder(scheduled.id); err != nil {
+			// people who already boarded keep riding, the simulation goes on
+			c.log().Warn("cancel", "tick", c.tick, "orderId", scheduled.id, "error", err.Error())
+		}
+	}
+	c.scheduledCancellations = remainingCancellations
+}
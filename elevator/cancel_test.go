@@ -0,0 +1,142 @@
+package elevator
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestController_CancelOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         int
+		id            int
+		wantErr       bool
+		wantBuffer    Orders
+		wantElevator  Elevator
+		wantCancelled int
+	}{
+		{
+			name:          "pending",
+			steps:         0,
+			id:            2,
+			wantBuffer:    Orders{{from: 5, to: 1, id: 1}},
+			wantElevator:  Elevator{index: 1, position: 0, state: StopAtFloor{Floor(0)}, motion: DefaultMotion, energyModel: DefaultEnergyModel},
+			wantCancelled: 1,
+		},
+		{
+			name:          "moving-empty",
+			steps:         3,
+			id:            1,
+			wantBuffer:    Orders{{from: 0, to: 3, id: 2}},
+			wantElevator:  Elevator{index: 1, position: 1, state: StopAtFloor{Floor(1)}, motion: DefaultMotion, energyModel: DefaultEnergyModel},
+			wantCancelled: 1,
+		},
+		{
+			name:         "boarded",
+			steps:        10,
+			id:           1,
+			wantErr:      true,
+			wantBuffer:   Orders{{from: 0, to: 3, id: 2}},
+			wantElevator: Elevator{index: 1, position: 4, currentOrder: Order{from: 5, to: 1, id: 1}, state: TransportingPeopleTo{Floor(1)}, motion: DefaultMotion, energyModel: DefaultEnergyModel},
+		},
+		{
+			name:         "unknown",
+			steps:        0,
+			id:           9,
+			wantErr:      true,
+			wantBuffer:   Orders{{from: 5, to: 1, id: 1}, {from: 0, to: 3, id: 2}},
+			wantElevator: Elevator{index: 1, position: 0, state: StopAtFloor{Floor(0)}, motion: DefaultMotion, energyModel: DefaultEnergyModel},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.PushOrder(5, 1)
+			controller.PushOrder(0, 3)
+			for i := 0; i < tt.steps; i++ {
+				controller.step()
+			}
+
+			err := controller.CancelOrder(tt.id)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CancelOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(controller.ordersBuffer, tt.wantBuffer) {
+				t.Errorf("ordersBuffer = %v, want %v", controller.ordersBuffer, tt.wantBuffer)
+			}
+			if got := controller.elevators[1]; !reflect.DeepEqual(got, tt.wantElevator) {
+				t.Errorf("elevator = %+v, want %+v", got, tt.wantElevator)
+			}
+			if controller.Metrics().CancelledOrders != tt.wantCancelled {
+				t.Errorf("CancelledOrders = %d, want %d", controller.Metrics().CancelledOrders, tt.wantCancelled)
+			}
+			if cancelled := controller.trips[tt.id].Cancelled; cancelled != (tt.wantCancelled > 0) {
+				t.Errorf("trip cancelled = %v", cancelled)
+			}
+		})
+	}
+}
+
+func TestController_CancelOrder_rider(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 8)
+	controller.PushOrder(3, 6)
+	for i := 0; i < 7; i++ {
+		controller.step()
+	}
+	if want := (Orders{{from: 3, to: 6, id: 2}}); !reflect.DeepEqual(controller.elevators[1].riders, want) {
+		t.Fatalf("riders = %v, want %v picked up on the way", controller.elevators[1].riders, want)
+	}
+
+	err := controller.CancelOrder(2)
+
+	if err == nil || err.Error() != "order [3->6] n°2 can not be cancelled, people boarded elevator n°1" {
+		t.Errorf("CancelOrder() error = %v", err)
+	}
+	if controller.Metrics().CancelledOrders != 0 || controller.trips[2].Cancelled {
+		t.Errorf("the order of the riders should not be cancelled, got %+v", controller.trips[2])
+	}
+}
+
+func TestController_CancelOrder_log(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _ := NewLogger(out, "info", "json")
+	controller := NewController(0)
+	controller.SetLogger(logger)
+	controller.AddElevator(1)
+	controller.PushOrder(5, 1)
+
+	controller.CancelOrder(1)
+
+	want := []map[string]any{
+		{"level": "INFO", "msg": "cancel", "tick": 0.0, "order": "[5->1]", "orderId": 1.0, "elevator": -1.0},
+	}
+	if got := logLines(t, out); !reflect.DeepEqual(got, want) {
+		t.Errorf("logs = %v, want %v", got, want)
+	}
+}
+
+func TestController_ScheduleCancellation(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(5, 1)
+	controller.PushOrder(0, 3)
+	controller.ScheduleCancellation(2, 1)
+	controller.ScheduleCancellation(2, 7)
+
+	for !controller.isOver() {
+		controller.step()
+	}
+
+	trips := controller.Trips()
+	if !trips[0].Cancelled || trips[0].Delivered() || trips[1].Cancelled || !trips[1].Delivered() {
+		t.Errorf("order 1 should be cancelled and order 2 delivered, got %+v", trips)
+	}
+	if controller.Metrics().CancelledOrders != 1 || len(controller.scheduledCancellations) != 0 {
+		t.Errorf("metrics = %s, scheduled cancellations = %v", controller.Metrics(), controller.scheduledCancellations)
+	}
+}
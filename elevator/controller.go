@@ -1,18 +1,54 @@
 package elevator
 
 import (
-	"errors"
 	"fmt"
 	"github.com/mariomac/gostream/stream"
 	"golang.org/x/exp/maps"
+	"log/slog"
 	"reflect"
+	"sort"
 	"time"
 )
 
 type Controller struct {
-	elevators       map[int]Elevator
-	ordersBuffer    Orders
-	pauseTimeInSecs int
+	elevators              map[int]Elevator
+	ordersBuffer           Orders
+	rejectedOrders         Orders
+	pauseTimeInSecs        int
+	tick                   int
+	scheduledOrders        []scheduledOrder
+	scheduledFaults        []scheduledFault
+	randomFaults           *randomFaults
+	detectedFaults         map[int]Fault
+	metrics                Metrics
+	mode                   OperationMode
+	recallFloor            Floor
+	scheduledRecalls       []scheduledRecall
+	dispatchMode           DispatchMode
+	maxExtraWaitTicks      int
+	parkingPolicy          ParkingPolicy
+	recentPickups          []Floor
+	renderer               Renderer
+	screen                 *AnsiScreen
+	lastOrderID            int
+	trips                  map[int]Trip
+	recording              bool
+	snapshots              []Snapshot
+	exporter               *PrometheusExporter
+	changedTrips           []int
+	logger                 *slog.Logger
+	checkInvariants        bool
+	violations             []Violation
+	agingTicks             int
+	maxWaitTicks           int
+	scheduledCancellations []scheduledCancellation
+	building               *Building
+	reassignmentThreshold  int
+}
+
+type scheduledOrder struct {
+	tick  int
+	order Order
 }
 
 func NewController(pauseTimeInSecs int) *Controller {
@@ -23,15 +59,20 @@ func NewController(pauseTimeInSecs int) *Controller {
 	}
 }
 
-func (c *Controller) AddElevator(index int) bool {
+func (c *Controller) AddElevator(index int, options ...ElevatorOption) bool {
 
 	_, ok := c.elevators[index]
 	if !ok {
 		elevator := Elevator{
 			index:        index,
 			currentOrder: Order{},
-			position:     Floor(0),
-			state:        StopAtFloor{Floor(0)},
+			position:     floorFromInt(c.Building().MinFloor),
+			state:        StopAtFloor{floorFromInt(c.Building().MinFloor)},
+			motion:       DefaultMotion,
+			energyModel:  DefaultEnergyModel,
+		}
+		for _, option := range options {
+			option(&elevator)
 		}
 
 		c.elevators[index] = elevator
@@ -41,82 +82,230 @@ func (c *Controller) AddElevator(index int) bool {
 	}
 }
 
-func (c *Controller) display() string {
-	display := "\n\n\tElevators state: \n"
-	display += fmt.Sprintf("\tOrdersBuffer: %v\n\n", c.ordersBuffer)
-	//display += "**********************************************************************\n\n"
-	stream.OfSlice(maps.Values(c.elevators)).
-		Sorted(sortElevatorsByIndex).
-		ForEach(func(e Elevator) {
-			display += fmt.Sprintf("%s\n", e.display())
-		})
+func (c *Controller) sortedIndexes() []int {
+	indexes := maps.Keys(c.elevators)
+	sort.Ints(indexes)
+	return indexes
+}
+
+func (c *Controller) SetOutOfService(index int) error {
+	elevator, ok := c.elevators[index]
+	if !ok {
+		return fmt.Errorf("there is no elevator n°%d in the system", index)
+	}
+
+	newElevator, orderToReassign := elevator.takeOutOfService()
+	c.elevators[index] = newElevator
+	if (Order{}) != orderToReassign {
+		// the order was the oldest one when dispatched, it goes back at the head of the buffer
+		c.requeueOrder(orderToReassign)
+	}
+	return nil
+}
+
+func (c *Controller) RemoveElevator(index int) error {
+	err := c.SetOutOfService(index)
+	if err != nil {
+		return err
+	}
+
+	elevator := c.elevators[index]
+	elevator.removing = true
+	c.elevators[index] = elevator
+	c.removeParkedElevators()
+	return nil
+}
+
+func (c *Controller) removeParkedElevators() {
+	for index, elevator := range c.elevators {
+		if elevator.removing && elevator.isParked() {
+			delete(c.elevators, index)
+		}
+	}
+}
+
+func (c *Controller) inServiceElevators() []Elevator {
+	return stream.OfSlice(maps.Values(c.elevators)).
+		Filter(func(e Elevator) bool {
+			return !e.outOfService
+		}).
+		ToSlice()
+}
+
+func (c *Controller) PushOrder(from int, to int) error {
+	return c.PushPriorityOrder(from, to, NormalPriority)
+}
+
+// PushPriorityOrder queues the order ahead of all orders with a lower priority
+func (c *Controller) PushPriorityOrder(from int, to int, priority Priority) error {
+	return c.PushWeightedOrder(from, to, priority, 0)
+}
+
+func (c *Controller) pushOrder(newOrder Order) error {
+	c.tripPushed(newOrder)
+	if !c.Building().contains(newOrder.from) || !c.Building().contains(newOrder.to) {
+		c.rejectOrder(newOrder)
+		return fmt.Errorf("order %s rejected, the building floors are [%d-%d]", newOrder, c.Building().MinFloor, c.Building().MaxFloor)
+	}
+	if len(c.elevators) > 0 && !c.canServe(newOrder) {
+		c.rejectOrder(newOrder)
+		return fmt.Errorf("order %s rejected, no elevator serves both floor %d and floor %d", newOrder, newOrder.from.toInt(), newOrder.to.toInt())
+	}
+	c.ordersBuffer = c.ordersBuffer.enqueue(newOrder)
+	c.recordPickup(newOrder.from)
+	return nil
+}
+
+// ScheduleOrder pushes the order when the simulation reaches the given tick
+func (c *Controller) ScheduleOrder(tick int, from int, to int) {
+	c.SchedulePriorityOrder(tick, from, to, NormalPriority)
+}
+
+func (c *Controller) SchedulePriorityOrder(tick int, from int, to int, priority Priority) {
+	c.ScheduleWeightedOrder(tick, from, to, priority, 0)
+}
+
+func (c *Controller) ScheduleWeightedOrder(tick int, from int, to int, priority Priority, weight int) {
+	c.scheduledOrders = append(c.scheduledOrders, scheduledOrder{tick: tick, order: Order{from: Floor(from), to: Floor(to), priority: priority, weight: weight}})
+}
+
+func (c *Controller) pushScheduledOrders() {
+	remainingOrders := []scheduledOrder{}
+	for _, scheduled := range c.scheduledOrders {
+		if scheduled.tick <= c.tick {
+			// a rejected order is kept in the rejected orders, the simulation goes on
+			_ = c.PushWeightedOrder(scheduled.order.from.toInt(), scheduled.order.to.toInt(), scheduled.order.priority, scheduled.order.weight)
+		} else {
+			remainingOrders = append(remainingOrders, scheduled)
+		}
+	}
+	c.scheduledOrders = remainingOrders
+}
+
+func (c *Controller) rejectOrder(order Order) {
+	c.rejectedOrders = append(c.rejectedOrders, order)
+	c.tripRejected(order)
+	c.log().Info("reject", "tick", c.tick, "order", order.String(), "orderId", order.id)
+}
 
-	return display
+// requeueOrder gives back to the buffer an order taken from an elevator before people boarded
+func (c *Controller) requeueOrder(order Order) {
+	c.ordersBuffer = c.ordersBuffer.requeue(order)
+	c.tripRequeued(order)
 }
 
-func (c *Controller) PushOrder(from int, to int) {
-	newOrder := Order{from: Floor(from), to: Floor(to)}
-	newBuffer := append(c.ordersBuffer, newOrder)
-	c.ordersBuffer = newBuffer
+func (c *Controller) canServe(order Order) bool {
+	return stream.OfSlice(c.inServiceElevators()).AnyMatch(func(e Elevator) bool {
+		return e.canServe(order)
+	})
+}
+
+func (c *Controller) rejectUnservableOrders() {
+	servableOrders := Orders{}
+	for _, order := range c.ordersBuffer {
+		if c.canServe(order) {
+			servableOrders = append(servableOrders, order)
+		} else {
+			c.rejectOrder(order)
+		}
+	}
+	if len(servableOrders) < len(c.ordersBuffer) {
+		c.ordersBuffer = servableOrders
+	}
 }
 
 func (c *Controller) popOrderFromBuffer() error {
-	if len(c.elevators) > 0 {
+	if c.mode == FireRecall {
+		// orders stay frozen in the buffer until the recall is cleared
+		return nil
+	} else {
 
-		if len(c.ordersBuffer) > 0 {
-			nextOrder := c.ordersBuffer[0]
+		// elevators taken out of service or removed may leave orders that nobody can serve anymore, an empty fleet rejects them all
+		c.rejectUnservableOrders()
+		c.ageOrdersBuffer()
 
-			elevators := maps.Values(c.elevators)
-			sortedElevators := stream.OfSlice(elevators).
+		// candidates are taken by index, so that ties are always broken the same way
+		elevators := []Elevator{}
+		for _, index := range c.sortedIndexes() {
+			elevators = append(elevators, c.elevators[index])
+		}
+		// orders are taken in FIFO order, but an order waiting for its zone does not block the other zones
+		for orderIndex, nextOrder := range c.ordersBuffer {
+			candidates := stream.OfSlice(elevators).
 				Filter(func(e Elevator) bool {
-					return e.isReadyForNewOrder()
+					return (e.isReadyFor(nextOrder) || c.canPreempt(e, nextOrder)) && e.canServe(nextOrder)
+				}).
+				Map(func(e Elevator) Elevator {
+					// a preempted elevator competes from where it is, as if it had no order
+					if c.canPreempt(e, nextOrder) {
+						releasedElevator, _ := e.releaseOrder()
+						return releasedElevator
+					}
+					return e
 				}).
+				ToSlice()
+			pickupTicks := estimatePickupTicks(candidates, nextOrder)
+			sortedElevators := stream.OfSlice(candidates).
 				Sorted(func(left Elevator, right Elevator) int {
-					return sortElevatorsByDistance(left, right, nextOrder)
+					return sortElevatorsByDistance(left, right, pickupTicks)
 				}).
 				ToSlice()
 
 			if len(sortedElevators) > 0 {
-				elevatorToUpdate := sortedElevators[0]
+				elevatorToUpdate := c.chooseElevator(sortedElevators, nextOrder, pickupTicks)
 				newElevator, err := elevatorToUpdate.addOrder(nextOrder)
 				if err == nil {
+					previousElevator := c.elevators[elevatorToUpdate.index]
+					preempted := c.canPreempt(previousElevator, nextOrder)
+					c.logDispatch(nextOrder, sortedElevators, elevatorToUpdate, preempted)
 					c.elevators[elevatorToUpdate.index] = newElevator
-					c.ordersBuffer = c.ordersBuffer[1:]
+					c.ordersBuffer = removeOrder(c.ordersBuffer, orderIndex)
+					c.tripAssigned(nextOrder, newElevator.index)
+					if preempted {
+						c.requeueOrder(previousElevator.currentOrder)
+					}
 					return nil
 				} else {
 					return err
 				}
-			} else {
-				return nil
 			}
-
-		} else {
-			return nil
 		}
+		return nil
+	}
+}
 
-	} else {
-		return errors.New("there is no elevator configured in the system currently to receive orders")
+func removeOrder(orders Orders, index int) Orders {
+	return append(orders[:index:index], orders[index+1:]...)
+}
+
+// estimatePickupTicks estimates once, for each candidate, the ticks until it picks the people of the order up.
+// Like EstimateArrival, it ignores the other orders waiting in the buffer, which are dispatched after this one
+func estimatePickupTicks(elevators []Elevator, newOrder Order) map[int]int {
+	pickupTicks := map[int]int{}
+	for _, elevator := range elevators {
+		pickupTicks[elevator.index] = elevator.pickupTicks(newOrder)
 	}
+	return pickupTicks
 }
 
-func sortElevatorsByDistance(left Elevator, right Elevator, newOrder Order) int {
+func sortElevatorsByDistance(left Elevator, right Elevator, pickupTicks map[int]int) int {
 
-	leftStateIsFree := reflect.TypeOf(left.state).Name() == "StopAtFloor"
-	rightStateIsFree := reflect.TypeOf(right.state).Name() == "StopAtFloor"
+	leftStateIsFree := reflect.TypeOf(left.state).Name() == "StopAtFloor" || reflect.TypeOf(left.state).Name() == "RepositioningTo"
+	rightStateIsFree := reflect.TypeOf(right.state).Name() == "StopAtFloor" || reflect.TypeOf(right.state).Name() == "RepositioningTo"
 
 	if leftStateIsFree && !rightStateIsFree {
 		return -1
 	} else if !leftStateIsFree && rightStateIsFree {
 		return 1
 	} else {
-		leftRemainingDistance := left.remainingDistance(newOrder)
-		rightRemainingDistance := right.remainingDistance(newOrder)
-		if leftRemainingDistance < rightRemainingDistance {
+		leftRemainingTime := pickupTicks[left.index]
+		rightRemainingTime := pickupTicks[right.index]
+		if leftRemainingTime < rightRemainingTime {
 			return -1
-		} else if leftRemainingDistance > rightRemainingDistance {
+		} else if leftRemainingTime > rightRemainingTime {
 			return 1
 		} else {
-			return 0
+			return sortElevatorsByIndex(left, right)
 		}
 	}
 }
@@ -131,30 +320,117 @@ func sortElevatorsByIndex(left Elevator, right Elevator) int {
 	}
 }
 
+func (c *Controller) step() error {
+	c.tick++
+
+	newElevator := map[int]Elevator{}
+	for index, v := range c.elevators {
+		newElevator[index] = v.nextState()
+	}
+	c.recordEnergy(c.elevators, newElevator)
+	c.boardWithinRatedLoad(c.elevators, newElevator)
+	c.recordTrips(c.elevators, newElevator)
+	c.recordRiderTrips(c.elevators, newElevator)
+	c.logTransitions(c.elevators, newElevator)
+	if c.checkInvariants {
+		c.checkTransitions(c.elevators, newElevator)
+	}
+	previousElevators := c.elevators
+	c.elevators = newElevator
+	c.removeParkedElevators()
+
+	c.injectFaults()
+	c.detectFaults()
+	c.pushScheduledOrders()
+	c.applyScheduledCancellations()
+	err := c.applyScheduledRecalls()
+	if err != nil {
+		return err
+	}
+
+	c.pickUpOnTheWay(previousElevators)
+	err = c.popOrderFromBuffer()
+	if err != nil {
+		return err
+	}
+	c.reassignOrders()
+	c.logWaitingOrders()
+	c.parkFreeElevators()
+	if c.checkInvariants {
+		c.checkAssignments()
+	}
+	if c.recording {
+		c.snapshots = append(c.snapshots, c.Snapshot())
+	}
+	if c.exporter != nil {
+		c.exporter.update(c)
+	}
+	return nil
+}
+
+func (c *Controller) isOver() bool {
+	// without a scheduled clearance, frozen orders will never be dispatched
+	return (len(c.ordersBuffer) == 0 || c.mode == FireRecall) &&
+		len(c.scheduledOrders) == 0 &&
+		len(c.scheduledRecalls) == 0 &&
+		stream.OfSlice(maps.Values(c.elevators)).AllMatch(Elevator.isIdle)
+}
+
 func (c *Controller) Run() {
 
 	for true {
 
-		fmt.Println(c.display())
-
-		newElevator := map[int]Elevator{}
-		for index, v := range c.elevators {
-			newElevator[index] = v.nextState()
+		if c.screen != nil {
+			c.screen.draw(c.display() + c.statusLine())
+		} else {
+			fmt.Println(c.display())
 		}
-		c.elevators = newElevator
 
-		err := c.popOrderFromBuffer()
+		err := c.step()
 		if err != nil {
 			panic(fmt.Sprintf("%s", err))
 		}
 
 		time.Sleep(time.Duration(c.pauseTimeInSecs) * time.Second)
 
-		if len(c.ordersBuffer) == 0 && stream.OfSlice(maps.Values(c.elevators)).AllMatch(Elevator.isReadyForNewOrder) {
+		if c.isOver() {
 			break
 		}
 	}
 
 	fmt.Printf("\n\n**************** End of Simulation *******************\n\n")
+	fmt.Printf("\t%s\n\n", c.metrics)
+	if c.checkInvariants {
+		fmt.Printf("\tInvariant violations: %d\n", len(c.violations))
+		for _, violation := range c.violations {
+			fmt.Printf("\t  %s\n", violation)
+		}
+	}
+}
+
+// EnableRecording keeps the snapshot of every tick from now on, whatever drives the simulation
+func (c *Controller) EnableRecording() {
+	c.recording = true
+	c.snapshots = []Snapshot{c.Snapshot()}
+}
+
+func (c *Controller) Snapshots() []Snapshot {
+	return c.snapshots
 }
 
+// Record runs the simulation without display nor pause, at most maxTicks ticks, and gives the snapshot of every tick
+func (c *Controller) Record(maxTicks int) []Snapshot {
+	c.EnableRecording()
+	for c.tick < maxTicks {
+		err := c.step()
+		if err != nil {
+			panic(fmt.Sprintf("%s", err))
+		}
+
+		if c.isOver() {
+			break
+		}
+	}
+	c.reportStrandedOnBoard()
+	return c.snapshots
+}
@@ -21,7 +21,7 @@ func TestController_PushOrder(t *testing.T) {
 				ordersBuffer: Orders{Order{from: Floor(1), to: Floor(3)}},
 			},
 			newOrder: Order{from: Floor(4), to: Floor(2)},
-			want:     Orders{Order{from: Floor(1), to: Floor(3)}, Order{from: Floor(4), to: Floor(2)}},
+			want:     Orders{Order{from: Floor(1), to: Floor(3)}, Order{from: Floor(4), to: Floor(2), id: 1}},
 		},
 	}
 	for _, tt := range tests {
@@ -172,12 +172,45 @@ func Test_sortElevatorsByAvailability(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "faster-elevator-wins-despite-longer-distance",
+			elevators: []Elevator{
+				{
+					index:    1,
+					position: 3,
+					state:    StopAtFloor{Floor(3)},
+					motion:   Motion{Speed: 0.5},
+				},
+				{
+					index:    2,
+					position: 0,
+					state:    StopAtFloor{Floor(0)},
+					motion:   Motion{Speed: 3},
+				},
+			},
+			newOrder: Order{from: Floor(6), to: Floor(2)},
+			want: []Elevator{
+				{
+					index:    2,
+					position: 0,
+					state:    StopAtFloor{Floor(0)},
+					motion:   Motion{Speed: 3},
+				},
+				{
+					index:    1,
+					position: 3,
+					state:    StopAtFloor{Floor(3)},
+					motion:   Motion{Speed: 0.5},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			pickupTicks := estimatePickupTicks(tt.elevators, tt.newOrder)
 			sortedElevators := stream.OfSlice(tt.elevators).
 				Sorted(func(left Elevator, right Elevator) int {
-					return sortElevatorsByDistance(left, right, tt.newOrder)
+					return sortElevatorsByDistance(left, right, pickupTicks)
 				}).
 				ToSlice()
 			if !reflect.DeepEqual(sortedElevators, tt.want) {
@@ -321,3 +354,278 @@ func TestController_popOrderFromBuffer(t *testing.T) {
 		})
 	}
 }
+
+func TestController_PushOrder_zoning(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1, WithServedFloors(0, 1, 2, 3, 4))
+	controller.AddElevator(2, WithServedFloors(0, 5, 6, 7, 8, 9))
+
+	if err := controller.PushOrder(0, 7); err != nil {
+		t.Errorf("PushOrder() unexpected error = %v", err)
+	}
+
+	err := controller.PushOrder(3, 7)
+	if err == nil {
+		t.Fatalf("PushOrder() should have rejected the order")
+	}
+	if err.Error() != "order [3->7] rejected, no elevator serves both floor 3 and floor 7" {
+		t.Errorf("PushOrder() error = %v", err)
+	}
+
+	want := Orders{Order{from: Floor(0), to: Floor(7), id: 1}}
+	if !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, want)
+	}
+}
+
+func TestController_popOrderFromBuffer_zoning(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				position:     2,
+				state:        StopAtFloor{Floor(2)},
+				servedFloors: []Floor{0, 1, 2, 3, 4},
+			},
+			2: {
+				index:        2,
+				currentOrder: Order{from: Floor(5), to: Floor(8)},
+				position:     6,
+				state:        TransportingPeopleTo{Floor(8)},
+				servedFloors: []Floor{0, 5, 6, 7, 8, 9},
+			},
+		},
+		ordersBuffer: Orders{Order{from: Floor(6), to: Floor(9)}, Order{from: Floor(4), to: Floor(1)}},
+	}
+
+	if err := controller.popOrderFromBuffer(); err != nil {
+		t.Fatalf("popOrderFromBuffer() unexpected error = %v", err)
+	}
+
+	if got := controller.elevators[1].currentOrder; got != (Order{from: Floor(4), to: Floor(1)}) {
+		t.Errorf("low-rise elevator currentOrder = %v, want [4->1]", got)
+	}
+	want := Orders{Order{from: Floor(6), to: Floor(9)}}
+	if !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, want)
+	}
+}
+
+func TestController_SetOutOfService(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     3,
+				state:        MovingEmptyTo{Floor(5)},
+			},
+			2: {
+				index:    2,
+				position: 0,
+				state:    StopAtFloor{Floor(0)},
+			},
+		},
+		ordersBuffer: Orders{Order{from: Floor(1), to: Floor(6)}},
+	}
+
+	if err := controller.SetOutOfService(1); err != nil {
+		t.Fatalf("SetOutOfService() unexpected error = %v", err)
+	}
+
+	want := Orders{Order{from: Floor(5), to: Floor(2)}, Order{from: Floor(1), to: Floor(6)}}
+	if !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, want)
+	}
+	if controller.elevators[1].isReadyForNewOrder() {
+		t.Errorf("elevator out of service should not be ready for new order")
+	}
+
+	controller.popOrderFromBuffer()
+	if got := controller.elevators[2].currentOrder; got != (Order{from: Floor(5), to: Floor(2)}) {
+		t.Errorf("reassigned order = %v, want [5->2]", got)
+	}
+
+	if err := controller.SetOutOfService(7); err == nil || err.Error() != "there is no elevator n°7 in the system" {
+		t.Errorf("SetOutOfService() on unknown elevator error = %v", err)
+	}
+}
+
+func TestController_RemoveElevator(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(3)},
+				position:     2,
+				state:        TransportingPeopleTo{Floor(3)},
+			},
+			2: {
+				index:    2,
+				position: 0,
+				state:    StopAtFloor{Floor(0)},
+			},
+		},
+		ordersBuffer: Orders{},
+	}
+
+	controller.RemoveElevator(2)
+	if _, ok := controller.elevators[2]; ok {
+		t.Errorf("parked elevator n°2 should have been removed at once")
+	}
+
+	controller.RemoveElevator(1)
+	for tick := 0; tick < 3; tick++ {
+		if _, ok := controller.elevators[1]; !ok {
+			t.Fatalf("elevator n°1 removed before delivering its passengers, at tick %d", tick)
+		}
+		controller.elevators[1] = controller.elevators[1].nextState()
+		controller.removeParkedElevators()
+	}
+	if _, ok := controller.elevators[1]; ok {
+		t.Errorf("elevator n°1 should have been removed once parked, state %+v", controller.elevators[1])
+	}
+}
+
+func TestController_step_removeLastElevator(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(5, 1)
+	controller.PushOrder(2, 7)
+	controller.step()
+	controller.step()
+
+	// the elevator moving empty gives its order back and is removed at once
+	if err := controller.RemoveElevator(1); err != nil {
+		t.Fatalf("RemoveElevator() unexpected error = %v", err)
+	}
+	for tick := 0; tick < 3; tick++ {
+		if err := controller.step(); err != nil {
+			t.Fatalf("step() without elevators unexpected error = %v", err)
+		}
+	}
+
+	if len(controller.ordersBuffer) != 0 || !controller.isOver() {
+		t.Errorf("the orders should be rejected without elevators, buffer %v", controller.ordersBuffer)
+	}
+	if want := (Orders{{from: 5, to: 1, id: 1}, {from: 2, to: 7, id: 2}}); !reflect.DeepEqual(controller.rejectedOrders, want) {
+		t.Errorf("rejectedOrders = %v, want %v", controller.rejectedOrders, want)
+	}
+	for _, trip := range controller.Trips() {
+		if !trip.Rejected {
+			t.Errorf("trip %+v should be rejected", trip)
+		}
+	}
+}
+
+func TestController_popOrderFromBuffer_rejectsUnservableOrders(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				position:     0,
+				state:        StopAtFloor{Floor(0)},
+				servedFloors: []Floor{0, 1, 2},
+				outOfService: true,
+			},
+			2: {
+				index:        2,
+				position:     5,
+				state:        StopAtFloor{Floor(5)},
+				servedFloors: []Floor{0, 5, 6},
+			},
+		},
+		ordersBuffer: Orders{Order{from: Floor(1), to: Floor(2)}},
+	}
+
+	controller.popOrderFromBuffer()
+
+	if !reflect.DeepEqual(controller.ordersBuffer, Orders{}) {
+		t.Errorf("ordersBuffer = %+v, wanted empty", controller.ordersBuffer)
+	}
+	if !reflect.DeepEqual(controller.rejectedOrders, Orders{Order{from: Floor(1), to: Floor(2)}}) {
+		t.Errorf("rejectedOrders = %+v, wanted [1->2]", controller.rejectedOrders)
+	}
+}
+
+func TestController_PushPriorityOrder(t *testing.T) {
+	controller := NewController(0)
+	controller.PushOrder(1, 3)
+	controller.PushPriorityOrder(5, 0, VIPPriority)
+	controller.PushOrder(2, 4)
+	controller.PushPriorityOrder(6, 0, MedicalEmergencyPriority)
+
+	want := Orders{
+		Order{from: Floor(6), to: Floor(0), priority: MedicalEmergencyPriority, id: 4},
+		Order{from: Floor(5), to: Floor(0), priority: VIPPriority, id: 2},
+		Order{from: Floor(1), to: Floor(3), id: 1},
+		Order{from: Floor(2), to: Floor(4), id: 3},
+	}
+	if !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, want)
+	}
+}
+
+func TestController_popOrderFromBuffer_preemption(t *testing.T) {
+	tests := []struct {
+		name       string
+		newOrder   Order
+		wantOrder  Order
+		wantBuffer Orders
+	}{
+		{
+			name:       "medical-emergency-takes-elevator-moving-empty",
+			newOrder:   Order{from: Floor(3), to: Floor(0), priority: MedicalEmergencyPriority},
+			wantOrder:  Order{from: Floor(3), to: Floor(0), priority: MedicalEmergencyPriority},
+			wantBuffer: Orders{Order{from: Floor(6), to: Floor(8)}},
+		},
+		{
+			name:       "normal-order-waits",
+			newOrder:   Order{from: Floor(3), to: Floor(0)},
+			wantOrder:  Order{from: Floor(6), to: Floor(8)},
+			wantBuffer: Orders{Order{from: Floor(3), to: Floor(0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := Controller{
+				elevators: map[int]Elevator{
+					1: {
+						index:        1,
+						currentOrder: Order{from: Floor(6), to: Floor(8)},
+						position:     2,
+						state:        MovingEmptyTo{Floor(6)},
+					},
+				},
+				ordersBuffer: Orders{tt.newOrder},
+			}
+
+			if err := controller.popOrderFromBuffer(); err != nil {
+				t.Fatalf("popOrderFromBuffer() unexpected error = %v", err)
+			}
+
+			if got := controller.elevators[1].currentOrder; got != tt.wantOrder {
+				t.Errorf("currentOrder = %v, want %v", got, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(controller.ordersBuffer, tt.wantBuffer) {
+				t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, tt.wantBuffer)
+			}
+		})
+	}
+}
+
+func TestController_step_orderToTheUnloadingFloor(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.elevators[1] = Elevator{index: 1, currentOrder: Order{from: 1, to: 4}, position: 4, state: TransportingPeopleTo{Floor(4)}, motion: DefaultMotion, energyModel: DefaultEnergyModel}
+	controller.PushOrder(0, 4)
+
+	for i := 0; i < 30 && !controller.isOver(); i++ {
+		controller.step()
+	}
+
+	// the car unloads at floor 4 on the first tick: taken then, the new order would be mistaken for the delivered one and dropped
+	if trip := controller.Trips()[0]; !trip.Delivered() {
+		t.Errorf("the order to the unloading floor should be delivered, got %+v", trip)
+	}
+}
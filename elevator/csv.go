@@ -0,0 +1,66 @@
+package elevator
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+func csvTick(tick int) string {
+	if tick == notYet {
+		return ""
+	}
+	return strconv.Itoa(tick)
+}
+
+// WriteOrdersCSV writes one row per order, the ticks of the steps which did not happen are left empty
+func WriteOrdersCSV(out io.Writer, trips []Trip) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"id", "from", "to", "priority", "push_tick", "assign_tick", "pickup_tick", "delivery_tick", "elevator", "rejected", "cancelled"})
+	for _, trip := range trips {
+		writer.Write([]string{
+			strconv.Itoa(trip.ID),
+			strconv.Itoa(trip.From),
+			strconv.Itoa(trip.To),
+			trip.Priority.String(),
+			csvTick(trip.PushTick),
+			csvTick(trip.AssignTick),
+			csvTick(trip.PickupTick),
+			csvTick(trip.DeliveryTick),
+			csvTick(trip.Elevator),
+			strconv.FormatBool(trip.Rejected),
+			strconv.FormatBool(trip.Cancelled),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteElevatorsCSV writes one row per elevator and per tick, the order columns are empty when the elevator has no order
+func WriteElevatorsCSV(out io.Writer, snapshots []Snapshot) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"tick", "elevator", "position", "state", "order_id", "order_from", "order_to", "fault", "out_of_service"})
+	for _, snapshot := range snapshots {
+		for _, elevator := range snapshot.Elevators {
+			orderID, orderFrom, orderTo := "", "", ""
+			if elevator.Order != nil {
+				orderID = strconv.Itoa(elevator.Order.ID)
+				orderFrom = strconv.Itoa(elevator.Order.From)
+				orderTo = strconv.Itoa(elevator.Order.To)
+			}
+			writer.Write([]string{
+				strconv.Itoa(snapshot.Tick),
+				strconv.Itoa(elevator.Index),
+				strconv.Itoa(elevator.Position),
+				elevator.State,
+				orderID,
+				orderFrom,
+				orderTo,
+				elevator.Fault,
+				strconv.FormatBool(elevator.OutOfService),
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,104 @@
+package elevator
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteOrdersCSV(t *testing.T) {
+	trips := []Trip{
+		{ID: 1, From: 0, To: 2, Elevator: 1, PushTick: 0, AssignTick: 1, PickupTick: 2, DeliveryTick: 6},
+		{ID: 2, From: 5, To: 1, Priority: VIPPriority, Elevator: notYet, PushTick: 3, AssignTick: notYet, PickupTick: notYet, DeliveryTick: notYet, Cancelled: true},
+		{ID: 3, From: 3, To: 7, Elevator: notYet, PushTick: 4, AssignTick: notYet, PickupTick: notYet, DeliveryTick: notYet, Rejected: true},
+	}
+
+	want := "id,from,to,priority,push_tick,assign_tick,pickup_tick,delivery_tick,elevator,rejected,cancelled\n" +
+		"1,0,2,normal,0,1,2,6,1,false,false\n" +
+		"2,5,1,vip,3,,,,,false,true\n" +
+		"3,3,7,normal,4,,,,,true,false\n"
+
+	out := &bytes.Buffer{}
+	if err := WriteOrdersCSV(out, trips); err != nil {
+		t.Fatalf("WriteOrdersCSV() error = %v", err)
+	}
+	if got := out.String(); got != want {
+		t.Errorf("WriteOrdersCSV() = \n%v\n, want \n%v\n", got, want)
+	}
+}
+
+func TestWriteElevatorsCSV(t *testing.T) {
+	snapshots := []Snapshot{
+		{Tick: 0, Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 0, State: "StopAtFloor"},
+			{Index: 2, Position: 4, State: "StopAtFloor", OutOfService: true},
+		}},
+		{Tick: 1, Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 1, State: "MovingEmptyTo", Order: &OrderSnapshot{ID: 7, From: 3, To: 0}, Fault: "STUCK between floors"},
+			{Index: 2, Position: 4, State: "StopAtFloor", OutOfService: true},
+		}},
+	}
+
+	want := "tick,elevator,position,state,order_id,order_from,order_to,fault,out_of_service\n" +
+		"0,1,0,StopAtFloor,,,,,false\n" +
+		"0,2,4,StopAtFloor,,,,,true\n" +
+		"1,1,1,MovingEmptyTo,7,3,0,STUCK between floors,false\n" +
+		"1,2,4,StopAtFloor,,,,,true\n"
+
+	out := &bytes.Buffer{}
+	if err := WriteElevatorsCSV(out, snapshots); err != nil {
+		t.Fatalf("WriteElevatorsCSV() error = %v", err)
+	}
+	if got := out.String(); got != want {
+		t.Errorf("WriteElevatorsCSV() = \n%v\n, want \n%v\n", got, want)
+	}
+}
+
+func TestController_EnableRecording(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 1)
+	controller.step()
+
+	if controller.Snapshots() != nil {
+		t.Fatalf("Snapshots() = %v before the recording", controller.Snapshots())
+	}
+
+	controller.EnableRecording()
+	controller.step()
+	controller.step()
+
+	snapshots := controller.Snapshots()
+	if len(snapshots) != 3 || snapshots[0].Tick != 1 || snapshots[2].Tick != 3 {
+		t.Errorf("Snapshots() = %+v, want ticks 1 to 3", snapshots)
+	}
+}
+
+func TestWriteCSV_sameRunSameCSV(t *testing.T) {
+	export := func() string {
+		controller := NewController(0)
+		controller.AddElevator(1)
+		controller.AddElevator(2)
+		controller.AddElevator(3)
+		for _, order := range [][2]int{{0, 5}, {0, 7}, {3, 1}, {9, 0}, {2, 6}, {6, 2}} {
+			controller.PushOrder(order[0], order[1])
+		}
+		controller.ScheduleFault(3, 2, Fault{Kind: StuckBetweenFloors, Ticks: 3})
+		snapshots := controller.Record(100)
+
+		out := &bytes.Buffer{}
+		if err := WriteOrdersCSV(out, controller.Trips()); err != nil {
+			t.Fatalf("WriteOrdersCSV() error = %v", err)
+		}
+		if err := WriteElevatorsCSV(out, snapshots); err != nil {
+			t.Fatalf("WriteElevatorsCSV() error = %v", err)
+		}
+		return out.String()
+	}
+
+	first := export()
+	for run := 0; run < 5; run++ {
+		if export() != first {
+			t.Fatalf("the same run should always write the same CSV files")
+		}
+	}
+}
@@ -2,10 +2,17 @@ package elevator
 
 import (
 	"fmt"
+	"math"
 )
 
 type Floor int
 
+const (
+	// minFloor is the lowest floor of any building, maxFloor the highest floor of the default building
+	minFloor = 0
+	maxFloor = 9
+)
+
 func (f Floor) toInt() int {
 	return int(f)
 }
@@ -14,22 +21,171 @@ func floorFromInt(x int) Floor {
 	return Floor(x)
 }
 
+type Priority int
+
+const (
+	NormalPriority Priority = iota
+	VIPPriority
+	MedicalEmergencyPriority
+)
+
+func priorityFromString(priority string) (Priority, error) {
+	switch priority {
+	case "", "normal":
+		return NormalPriority, nil
+	case "vip":
+		return VIPPriority, nil
+	case "medical":
+		return MedicalEmergencyPriority, nil
+	default:
+		return NormalPriority, fmt.Errorf("unknown priority '%s', expected one of normal, vip, medical", priority)
+	}
+}
+
+func (p Priority) String() string {
+	switch p {
+	case VIPPriority:
+		return "vip"
+	case MedicalEmergencyPriority:
+		return "medical"
+	default:
+		return "normal"
+	}
+}
+
 type Order struct {
-	from Floor
-	to   Floor
+	from     Floor
+	to       Floor
+	priority Priority
+	// id is given when the order is pushed to the controller
+	id int
+	// weight is the estimated weight of the people in kilograms, 0 when unknown
+	weight int
 }
 
 func (o Order) String() string {
-	return fmt.Sprintf("[%d->%d]", o.from.toInt(), o.to.toInt())
+	switch o.priority {
+	case VIPPriority:
+		return fmt.Sprintf("{%d->%d}", o.from.toInt(), o.to.toInt())
+	case MedicalEmergencyPriority:
+		return fmt.Sprintf("<%d->%d>", o.from.toInt(), o.to.toInt())
+	default:
+		return fmt.Sprintf("[%d->%d]", o.from.toInt(), o.to.toInt())
+	}
 }
 
 type Orders []Order
 
+// enqueue keeps the orders sorted by priority, FIFO within the same priority
+func (orders Orders) enqueue(order Order) Orders {
+	position := len(orders)
+	for position > 0 && orders[position-1].priority < order.priority {
+		position--
+	}
+	return orders.insertAt(position, order)
+}
+
+// requeue puts back an order at the head of its priority, it was the oldest one when dispatched
+func (orders Orders) requeue(order Order) Orders {
+	position := 0
+	for position < len(orders) && orders[position].priority > order.priority {
+		position++
+	}
+	return orders.insertAt(position, order)
+}
+
+func (orders Orders) insertAt(position int, order Order) Orders {
+	newOrders := append(Orders{}, orders[:position]...)
+	newOrders = append(newOrders, order)
+	return append(newOrders, orders[position:]...)
+}
+
+// Motion describes how a car travels between floors: Speed is the cruise speed
+// in floors per tick, Acceleration the speed gained per tick when leaving a floor.
+// A zero Acceleration means the car starts directly at cruise speed.
+type Motion struct {
+	Speed        float64
+	Acceleration float64
+}
+
+var DefaultMotion = Motion{Speed: 1, Acceleration: 0}
+
+func MotionFromFloorToFloorTime(ticksPerFloor float64, acceleration float64) Motion {
+	return Motion{Speed: 1 / ticksPerFloor, Acceleration: acceleration}
+}
+
+func (m Motion) cruiseSpeed() float64 {
+	if m.Speed <= 0 {
+		return DefaultMotion.Speed
+	} else {
+		return m.Speed
+	}
+}
+
+func (m Motion) accelerate(velocity float64) float64 {
+	if m.Acceleration <= 0 {
+		return m.cruiseSpeed()
+	} else {
+		return math.Min(m.cruiseSpeed(), velocity+m.Acceleration)
+	}
+}
+
+const progressEpsilon = 1e-9
+
+type ElevatorOption func(*Elevator)
+
+func WithMotion(motion Motion) ElevatorOption {
+	return func(e *Elevator) {
+		e.motion = motion
+	}
+}
+
+// WithServedFloors restricts the elevator to the given floors, for low-rise/high-rise banks or service cars
+func WithServedFloors(floors ...int) ElevatorOption {
+	return func(e *Elevator) {
+		e.servedFloors = []Floor{}
+		for _, floor := range floors {
+			e.servedFloors = append(e.servedFloors, floorFromInt(floor))
+		}
+	}
+}
+
 type Elevator struct {
 	index        int
 	currentOrder Order
 	position     Floor
 	state        State
+	motion       Motion
+	velocity     float64
+	progress     float64
+	servedFloors []Floor
+	outOfService bool
+	removing     bool
+	fault        Fault
+	energyModel  EnergyModel
+	ratedLoad    int
+	// overloaded is set while the car loads more people than its rated load allows
+	overloaded bool
+	// riders are the orders picked up on the way, stopping is set while the doors open for them
+	riders   Orders
+	stopping bool
+}
+
+func (e Elevator) serves(floor Floor) bool {
+	// no declared zone means the elevator serves every floor
+	if e.servedFloors == nil {
+		return true
+	}
+	for _, servedFloor := range e.servedFloors {
+		if servedFloor == floor {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Elevator) canServe(order Order) bool {
+	return e.serves(order.from) && e.serves(order.to)
 }
 
 func (e Elevator) computeDistance(from Floor, to Floor) int {
@@ -41,70 +197,243 @@ func (e Elevator) computeDistance(from Floor, to Floor) int {
 	}
 }
 
+// isReadyFor tells if the order can be added now. An unloading car tells the order it delivers from its next
+// order by their destination, so it waits for its doors to close before taking an order to the same floor
+func (e Elevator) isReadyFor(order Order) bool {
+	if _, unloading := e.state.(UnloadingAtFloor); unloading && order.to == e.position {
+		return false
+	}
+	return e.isReadyForNewOrder()
+}
+
 func (e Elevator) isReadyForNewOrder() bool {
+	if e.outOfService || e.fault.isActive() {
+		return false
+	}
 	switch e.state.(type) {
 	case StopAtFloor:
 		return (Order{}) == e.currentOrder
 	case UnloadingAtFloor:
 		return true
+	case RepositioningTo:
+		// a parking move is interrupted by the next real order
+		return (Order{}) == e.currentOrder
+	default:
+		return false
+	}
+}
+
+// canBePreemptedBy tells if a more urgent order can take the elevator while it is still moving empty to a pickup
+func (e Elevator) canBePreemptedBy(order Order) bool {
+	return e.isMovingEmptyToPickup() && e.currentOrder.priority < order.priority
+}
+
+func (e Elevator) isMovingEmptyToPickup() bool {
+	_, movingEmpty := e.state.(MovingEmptyTo)
+	return movingEmpty && len(e.riders) == 0 && !e.outOfService && !e.fault.isActive()
+}
+
+func (e Elevator) isParked() bool {
+	_, stopped := e.state.(StopAtFloor)
+	return stopped && (Order{}) == e.currentOrder
+}
+
+func (e Elevator) isIdle() bool {
+	if e.fault.Kind == Breakdown {
+		// a broken down elevator will never move again, people on board are reported as stranded
+		return true
+	}
+	switch e.state.(type) {
+	case StopAtFloor:
+		return (Order{}) == e.currentOrder
+	case UnloadingAtFloor:
+		// no new order assigned while unloading
+		return e.currentOrder.to == e.position
 	default:
 		return false
 	}
 }
 
-func (e Elevator) remainingDistance(newOrder Order) int {
+func (e Elevator) isCarryingPeople() bool {
+	return len(e.riders) > 0 || e.hasBoardedCurrentOrder()
+}
+
+// boardedOrders are the orders whose people are on board
+func (e Elevator) boardedOrders() Orders {
+	orders := append(Orders{}, e.riders...)
+	if e.hasBoardedCurrentOrder() {
+		orders = append(orders, e.currentOrder)
+	}
+	return orders
+}
+
+func (e Elevator) hasBoardedCurrentOrder() bool {
 	switch e.state.(type) {
-	case StopAtFloor, UnloadingAtFloor:
-		return e.computeDistance(e.position, newOrder.from)
 	case LoadingAtFloor, TransportingPeopleTo:
-		return e.computeDistance(e.position, e.currentOrder.to) + e.computeDistance(e.currentOrder.to, newOrder.from)
+		return true
+	case RecallingTo:
+		return (Order{}) != e.currentOrder
+	default:
+		return false
+	}
+}
+
+// pendingPickup is the order assigned to the elevator while people still wait for it, if any
+func (e Elevator) pendingPickup() Order {
+	switch e.state.(type) {
+	case MovingEmptyTo, StopAtFloor, RepositioningTo:
+		return e.currentOrder
+	case UnloadingAtFloor:
+		if e.currentOrder.to != e.position {
+			return e.currentOrder
+		}
+	}
+	return Order{}
+}
+
+// releaseOrder returns the elevator without its order if nobody has boarded yet, and the released order
+func (e Elevator) releaseOrder() (Elevator, Order) {
+	switch e.state.(type) {
 	case MovingEmptyTo:
-		return e.computeDistance(e.position, e.currentOrder.from) + e.computeDistance(e.currentOrder.from, e.currentOrder.to) + e.computeDistance(e.currentOrder.to, newOrder.from)
+		if len(e.riders) > 0 {
+			// the people picked up on the way stay on board
+			return e.promoteRider(), e.currentOrder
+		}
+		newElevator := e
+		newElevator.velocity = 0
+		newElevator.progress = 0
+		return newElevator.withOrderAndState(Order{}, StopAtFloor{e.position}), e.currentOrder
+	case StopAtFloor:
+		return e.withOrderAndState(Order{}, StopAtFloor{e.position}), e.currentOrder
+	case RepositioningTo:
+		newElevator := e
+		newElevator.velocity = 0
+		newElevator.progress = 0
+		return newElevator.withOrderAndState(Order{}, StopAtFloor{e.position}), e.currentOrder
+	case UnloadingAtFloor:
+		if e.currentOrder.to != e.position {
+			// the next order was already assigned while unloading
+			return e.withOrderAndState(Order{}, e.state), e.currentOrder
+		} else {
+			return e, Order{}
+		}
 	default:
-		return 0
+		return e, Order{}
+	}
+}
+
+// takeOutOfService returns the elevator no longer accepting orders, and the order it gives back
+// if nobody has boarded yet. People already on board are delivered before the car parks
+func (e Elevator) takeOutOfService() (Elevator, Order) {
+	newElevator, releasedOrder := e.releaseOrder()
+	newElevator.outOfService = true
+	return newElevator, releasedOrder
+}
+
+// recallTo sends the elevator straight to the recall floor. It returns the order it gives back if nobody boarded yet
+func (e Elevator) recallTo(floor Floor) (Elevator, Order) {
+	newElevator, releasedOrder := e.releaseOrder()
+	carryingPeople := newElevator.isCarryingPeople()
+
+	recalledOrder := Order{}
+	if carryingPeople {
+		recalledOrder = newElevator.currentOrder
+	}
+	newElevator.stopping = false
+
+	if e.position == floor && !carryingPeople {
+		return newElevator.withOrderAndState(Order{}, StopAtFloor{floor}), releasedOrder
+	} else {
+		return newElevator.withOrderAndState(recalledOrder, RecallingTo{floor}), releasedOrder
+	}
+}
+
+func (e Elevator) withFault(fault Fault) Elevator {
+	newElevator := e
+	newElevator.fault = fault
+	newElevator.velocity = 0
+	if fault.Kind == DoorJam {
+		// the doors open at the floor the car stands at
+		newElevator.progress = 0
+	}
+	return newElevator
+}
+
+func (e Elevator) moveTowards(target Floor, state State) Elevator {
+	velocity := e.motion.accelerate(e.velocity)
+	progress := e.progress + velocity
+	position := e.position
+	for progress >= 1-progressEpsilon && position != target {
+		if position < target {
+			position++
+		} else {
+			position--
+		}
+		progress--
 	}
 
+	newElevator := e
+	newElevator.position = position
+	newElevator.state = state
+	newElevator.velocity = 0
+	newElevator.progress = 0
+	if position != target {
+		// without acceleration, the car always travels at cruise speed, no need to remember it
+		if e.motion.Acceleration > 0 {
+			newElevator.velocity = velocity
+		}
+		newElevator.progress = math.Max(progress, 0)
+	}
+	return newElevator
 }
 
 func (e Elevator) newPositionAndState(position int, state State) (Elevator, error) {
 	if position < 0 {
 		return Elevator{}, fmt.Errorf("Invalid negative position : %d", position)
 	} else {
-		return Elevator{
-			index:        e.index,
-			currentOrder: e.currentOrder,
-			position:     floorFromInt(position),
-			state:        state,
-		}, nil
+		newElevator := e
+		newElevator.position = floorFromInt(position)
+		newElevator.state = state
+		return newElevator, nil
 	}
 }
 
 func (e Elevator) addOrder(order Order) (Elevator, error) {
 	if (Order{}) == order {
 		return e, fmt.Errorf("cannot add empty order")
-	} else if order.from < 0 || order.from > 9 {
-		return e, fmt.Errorf("order.from %d is out of bound [0-9]", order.from.toInt())
-	} else if order.to < 0 || order.to > 9 {
-		return e, fmt.Errorf("order.to %d is out of bound [0-9]", order.to.toInt())
+	} else if order.from < minFloor {
+		// the highest floor depends on the building, which the controller checks when the order is pushed
+		return e, fmt.Errorf("order.from %d is below the lowest floor %d", order.from.toInt(), minFloor)
+	} else if order.to < minFloor {
+		return e, fmt.Errorf("order.to %d is below the lowest floor %d", order.to.toInt(), minFloor)
 	} else if order.from == order.to {
 		return e, fmt.Errorf("order.from %d should NOT be equal to order.to %d", order.from.toInt(), order.to.toInt())
-	} else if e.currentOrder.to.toInt() != e.position.toInt() {
+	} else if (Order{}) != e.currentOrder && e.currentOrder.to.toInt() != e.position.toInt() {
 		return e, fmt.Errorf("the elevator n°%d has not reached yet its destination, cannot add new order", e.index)
 	} else {
-		return Elevator{
-			index:        e.index,
-			currentOrder: order,
-			position:     e.position,
-			state:        e.state,
-		}, nil
+		newElevator := e
+		newElevator.currentOrder = order
+		return newElevator, nil
 	}
 
 }
 
+func (e Elevator) withOrderAndState(order Order, state State) Elevator {
+	newElevator := e
+	newElevator.currentOrder = order
+	newElevator.state = state
+	return newElevator
+}
+
 func (e Elevator) nextState() Elevator {
 	var newElevator Elevator
 	var newState State
 
+	if e.fault.isActive() {
+		// a faulty elevator does not move nor open its doors until the fault is cleared
+		return e.withFault(e.fault.elapse())
+	}
+
 	switch currentState := e.state.(type) {
 
 	case TransportingPeopleTo:
@@ -114,45 +443,23 @@ func (e Elevator) nextState() Elevator {
 		if currentPosition == to {
 			newState = UnloadingAtFloor{Floor(to)}
 			newElevator, _ = e.newPositionAndState(to, newState)
-		} else if currentPosition < to {
-			newState = TransportingPeopleTo{Floor(to)}
-			newElevator, _ = e.newPositionAndState(currentPosition+1, newState)
+			newElevator = newElevator.dropRiders()
 		} else {
 			newState = TransportingPeopleTo{Floor(to)}
-			newElevator, _ = e.newPositionAndState(currentPosition-1, newState)
+			newElevator = e.moveWithRiders(Floor(to), newState)
 		}
 		return newElevator
 
 	case UnloadingAtFloor:
 		currentOrder := e.currentOrder
 		if (Order{}) == currentOrder {
-			return Elevator{
-				index:        e.index,
-				currentOrder: e.currentOrder,
-				position:     e.position,
-				state:        StopAtFloor{e.position},
-			}
+			return e.withOrderAndState(e.currentOrder, StopAtFloor{e.position})
 		} else if e.position.toInt() == currentOrder.from.toInt() {
-			return Elevator{
-				index:        e.index,
-				currentOrder: e.currentOrder,
-				position:     e.position,
-				state:        LoadingAtFloor{e.position},
-			}
+			return e.withOrderAndState(e.currentOrder, LoadingAtFloor{e.position})
 		} else if e.position.toInt() == e.currentOrder.to.toInt() {
-			return Elevator{
-				index:        e.index,
-				currentOrder: Order{},
-				position:     e.position,
-				state:        StopAtFloor{e.position},
-			}
+			return e.withOrderAndState(Order{}, StopAtFloor{e.position})
 		} else {
-			return Elevator{
-				index:        e.index,
-				currentOrder: e.currentOrder,
-				position:     e.position,
-				state:        MovingEmptyTo{currentOrder.from},
-			}
+			return e.withOrderAndState(e.currentOrder, MovingEmptyTo{currentOrder.from})
 		}
 
 	case MovingEmptyTo:
@@ -162,24 +469,17 @@ func (e Elevator) nextState() Elevator {
 		if currentPosition == to {
 			newState = LoadingAtFloor{Floor(to)}
 			newElevator, _ = e.newPositionAndState(to, newState)
-		} else if currentPosition < to {
-			newState = currentState
-			newElevator, _ = e.newPositionAndState(currentPosition+1, newState)
+			newElevator = newElevator.dropRiders()
 		} else {
 			newState = currentState
-			newElevator, _ = e.newPositionAndState(currentPosition-1, newState)
+			newElevator = e.moveWithRiders(Floor(to), newState)
 		}
 		return newElevator
 
 	case LoadingAtFloor:
 		currentOrder := e.currentOrder
 		newState = TransportingPeopleTo{currentOrder.to}
-		return Elevator{
-			index:        e.index,
-			currentOrder: currentOrder,
-			position:     e.position,
-			state:        newState,
-		}
+		return e.withOrderAndState(currentOrder, newState)
 
 	case StopAtFloor:
 		currentOrder := e.currentOrder
@@ -192,20 +492,38 @@ func (e Elevator) nextState() Elevator {
 				newState = MovingEmptyTo{currentOrder.from}
 			}
 		}
-		return Elevator{
-			index:        e.index,
-			currentOrder: currentOrder,
-			position:     e.position,
-			state:        newState,
+		return e.withOrderAndState(currentOrder, newState)
+
+	case RepositioningTo:
+		if (Order{}) != e.currentOrder {
+			// the parking move is interrupted by a real order
+			if e.position == e.currentOrder.from {
+				newElevator = e.withOrderAndState(e.currentOrder, LoadingAtFloor{e.position})
+				newElevator.velocity = 0
+				newElevator.progress = 0
+				return newElevator
+			} else {
+				return e.withOrderAndState(e.currentOrder, MovingEmptyTo{e.currentOrder.from})
+			}
+		} else if e.position == currentState.floor() {
+			return e.withOrderAndState(Order{}, StopAtFloor{e.position})
+		} else {
+			return e.moveTowards(currentState.floor(), currentState)
+		}
+
+	case RecallingTo:
+		to := currentState.floor()
+
+		if e.position == to {
+			// everybody leaves the elevator at the recall floor, the elevator parks doors open
+			newElevator = e.withOrderAndState(Order{}, StopAtFloor{to})
+			newElevator.riders = nil
+			return newElevator
+		} else {
+			return e.moveTowards(to, currentState)
 		}
 
 	default:
 		panic(fmt.Sprintf("Unknown type: %T", currentState))
 	}
 }
-
-func (e Elevator) display() string {
-	stateDisplay := e.state.display(e.currentOrder, e.position.toInt())
-	display := fmt.Sprintf("%d %s", e.index, stateDisplay)
-	return display
-}
@@ -97,89 +97,6 @@ func TestElevator_newPositionAndState(t *testing.T) {
 	}
 }
 
-func TestElevator_remainingDistance(t *testing.T) {
-	tests := []struct {
-		name     string
-		elevator Elevator
-		newOrder Order
-		want     int
-	}{
-		{
-			name: "transporting-at-floor",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(1), to: Floor(3)},
-				position:     2,
-				state:        TransportingPeopleTo{Floor(3)},
-			},
-			newOrder: Order{from: Floor(4), to: Floor(2)},
-			want:     2,
-		},
-		{
-			name: "stopped-at-floor",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{},
-				position:     2,
-				state:        StopAtFloor{Floor(2)},
-			},
-			newOrder: Order{from: Floor(5), to: Floor(2)},
-			want:     3,
-		},
-		{
-			name: "unloading-at-target-floor",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(1), to: Floor(3)},
-				position:     Floor(3),
-				state:        UnloadingAtFloor{Floor(3)},
-			},
-			newOrder: Order{from: Floor(4), to: Floor(2)},
-			want:     1,
-		},
-		{
-			name: "descending",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(5), to: Floor(1)},
-				position:     Floor(4),
-				state:        TransportingPeopleTo{Floor(1)},
-			},
-			newOrder: Order{from: Floor(2), to: Floor(3)},
-			want:     4,
-		},
-		{
-			name: "loading-at-source-floor",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(5), to: Floor(3)},
-				position:     Floor(5),
-				state:        LoadingAtFloor{Floor(5)},
-			},
-			newOrder: Order{from: Floor(6), to: Floor(2)},
-			want:     5,
-		},
-		{
-			name: "moving-empty-scenario",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(5), to: Floor(3)},
-				position:     Floor(1),
-				state:        MovingEmptyTo{Floor(5)},
-			},
-			newOrder: Order{from: Floor(4), to: Floor(2)},
-			want:     7,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.elevator.remainingDistance(tt.newOrder); got != tt.want {
-				t.Errorf("remainingDistance() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestFloor_toInt(t *testing.T) {
 	tests := []struct {
 		name string
@@ -245,6 +162,22 @@ func TestElevator_addOrder(t *testing.T) {
 				state:        StopAtFloor{Floor(0)},
 			},
 		},
+		{
+			name: "stopped-away-from-ground-floor",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{},
+				position:     Floor(4),
+				state:        StopAtFloor{Floor(4)},
+			},
+			newOrder: Order{from: Floor(6), to: Floor(5)},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(6), to: Floor(5)},
+				position:     Floor(4),
+				state:        StopAtFloor{Floor(4)},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -271,17 +204,7 @@ func TestElevator_addOrder_failures(t *testing.T) {
 				position:     1,
 			},
 			newOrder:   Order{from: Floor(-1), to: Floor(2)},
-			failureMsg: "order.from -1 is out of bound [0-9]",
-		},
-		{
-			name: "order.from-too-big",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{},
-				position:     1,
-			},
-			newOrder:   Order{from: Floor(10), to: Floor(2)},
-			failureMsg: "order.from 10 is out of bound [0-9]",
+			failureMsg: "order.from -1 is below the lowest floor 0",
 		},
 		{
 			name: "order.to-negative",
@@ -291,17 +214,7 @@ func TestElevator_addOrder_failures(t *testing.T) {
 				position:     1,
 			},
 			newOrder:   Order{from: Floor(1), to: Floor(-2)},
-			failureMsg: "order.to -2 is out of bound [0-9]",
-		},
-		{
-			name: "order.to-too-big",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{},
-				position:     1,
-			},
-			newOrder:   Order{from: Floor(1), to: Floor(10)},
-			failureMsg: "order.to 10 is out of bound [0-9]",
+			failureMsg: "order.to -2 is below the lowest floor 0",
 		},
 		{
 			name: "order.from-equals-order.to",
@@ -342,10 +255,6 @@ func (u UnknownState) floor() Floor {
 	return Floor(0)
 }
 
-func (u UnknownState) display(currentOrder Order, currentPosition int) string {
-	return ""
-}
-
 func TestElevator_nextState(t *testing.T) {
 
 	tests := []struct {
@@ -638,33 +547,6 @@ func TestElevator_nextState_panic(t *testing.T) {
 	currentState.nextState()
 }
 
-func TestElevator_display(t *testing.T) {
-	tests := []struct {
-		name     string
-		elevator Elevator
-		want     string
-	}{
-		{
-			name: "nominal",
-			elevator: Elevator{
-				index:        1,
-				currentOrder: Order{from: Floor(2), to: Floor(4)},
-				position:     4,
-				state:        UnloadingAtFloor{4},
-			},
-			want: "1 [2->4](UnloadingAtFloor)    : _  _  _  _ ↓4↓",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			if got := tt.elevator.display(); got != tt.want {
-				t.Errorf("String() = \n%v\n, want \n%v\n", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestElevator_isReadyForNewOrder(t *testing.T) {
 
 	tests := []struct {
@@ -722,3 +604,419 @@ func TestElevator_isReadyForNewOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestElevator_isReadyFor(t *testing.T) {
+
+	unloading := Elevator{
+		index:        1,
+		currentOrder: Order{from: Floor(1), to: Floor(4)},
+		position:     4,
+		state:        UnloadingAtFloor{Floor(4)},
+	}
+	tests := []struct {
+		name     string
+		elevator Elevator
+		order    Order
+		want     bool
+	}{
+		{
+			name:     "unloading-new-order-elsewhere",
+			elevator: unloading,
+			order:    Order{from: Floor(6), to: Floor(2)},
+			want:     true,
+		},
+		{
+			name:     "unloading-new-order-from-floor",
+			elevator: unloading,
+			order:    Order{from: Floor(4), to: Floor(0)},
+			want:     true,
+		},
+		{
+			name:     "unloading-new-order-to-same-floor",
+			elevator: unloading,
+			order:    Order{from: Floor(0), to: Floor(4)},
+			want:     false,
+		},
+		{
+			name: "stopped-new-order-to-same-floor",
+			elevator: Elevator{
+				index:    1,
+				position: 4,
+				state:    StopAtFloor{Floor(4)},
+			},
+			order: Order{from: Floor(0), to: Floor(4)},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := tt.elevator.isReadyFor(tt.order); got != tt.want {
+				t.Errorf("isReadyFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElevator_moveTowards(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		target   Floor
+		want     Elevator
+	}{
+		{
+			name: "express-two-floors-per-tick",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(1),
+				state:    TransportingPeopleTo{Floor(6)},
+				motion:   Motion{Speed: 2},
+			},
+			target: Floor(6),
+			want: Elevator{
+				index:    1,
+				position: Floor(3),
+				state:    TransportingPeopleTo{Floor(6)},
+				motion:   Motion{Speed: 2},
+			},
+		},
+		{
+			name: "express-does-not-overshoot",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(5),
+				state:    TransportingPeopleTo{Floor(6)},
+				motion:   Motion{Speed: 2},
+			},
+			target: Floor(6),
+			want: Elevator{
+				index:    1,
+				position: Floor(6),
+				state:    TransportingPeopleTo{Floor(6)},
+				motion:   Motion{Speed: 2},
+			},
+		},
+		{
+			name: "slow-car-stays-between-floors",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(4),
+				state:    MovingEmptyTo{Floor(2)},
+				motion:   Motion{Speed: 0.5},
+			},
+			target: Floor(2),
+			want: Elevator{
+				index:    1,
+				position: Floor(4),
+				state:    MovingEmptyTo{Floor(2)},
+				motion:   Motion{Speed: 0.5},
+				progress: 0.5,
+			},
+		},
+		{
+			name: "slow-car-reaches-next-floor",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(4),
+				state:    MovingEmptyTo{Floor(2)},
+				motion:   Motion{Speed: 0.5},
+				progress: 0.5,
+			},
+			target: Floor(2),
+			want: Elevator{
+				index:    1,
+				position: Floor(3),
+				state:    MovingEmptyTo{Floor(2)},
+				motion:   Motion{Speed: 0.5},
+			},
+		},
+		{
+			name: "accelerating-car-keeps-velocity",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(0),
+				state:    TransportingPeopleTo{Floor(5)},
+				motion:   Motion{Speed: 1, Acceleration: 0.5},
+			},
+			target: Floor(5),
+			want: Elevator{
+				index:    1,
+				position: Floor(0),
+				state:    TransportingPeopleTo{Floor(5)},
+				motion:   Motion{Speed: 1, Acceleration: 0.5},
+				velocity: 0.5,
+				progress: 0.5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.moveTowards(tt.target, tt.elevator.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("moveTowards() = \n%+v\n, want \n%+v\n", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElevator_canServe(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		order    Order
+		want     bool
+	}{
+		{
+			name:     "no-zone-serves-all-floors",
+			elevator: Elevator{index: 1},
+			order:    Order{from: Floor(0), to: Floor(9)},
+			want:     true,
+		},
+		{
+			name:     "high-rise-bank",
+			elevator: Elevator{index: 1, servedFloors: []Floor{0, 5, 6, 7, 8, 9}},
+			order:    Order{from: Floor(0), to: Floor(7)},
+			want:     true,
+		},
+		{
+			name:     "high-rise-bank-skips-low-floors",
+			elevator: Elevator{index: 1, servedFloors: []Floor{0, 5, 6, 7, 8, 9}},
+			order:    Order{from: Floor(3), to: Floor(7)},
+			want:     false,
+		},
+		{
+			name:     "destination-not-served",
+			elevator: Elevator{index: 1, servedFloors: []Floor{0, 1, 2, 3, 4}},
+			order:    Order{from: Floor(3), to: Floor(7)},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.canServe(tt.order); got != tt.want {
+				t.Errorf("canServe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElevator_takeOutOfService(t *testing.T) {
+	tests := []struct {
+		name          string
+		elevator      Elevator
+		want          Elevator
+		wantGivenBack Order
+	}{
+		{
+			name: "moving-empty-gives-order-back",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     Floor(3),
+				state:        MovingEmptyTo{Floor(5)},
+				progress:     0.5,
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{},
+				position:     Floor(3),
+				state:        StopAtFloor{Floor(3)},
+				outOfService: true,
+			},
+			wantGivenBack: Order{from: Floor(5), to: Floor(2)},
+		},
+		{
+			name: "stopped-with-pending-order-gives-order-back",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     Floor(3),
+				state:        StopAtFloor{Floor(3)},
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{},
+				position:     Floor(3),
+				state:        StopAtFloor{Floor(3)},
+				outOfService: true,
+			},
+			wantGivenBack: Order{from: Floor(5), to: Floor(2)},
+		},
+		{
+			name: "unloading-gives-next-order-back",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     Floor(3),
+				state:        UnloadingAtFloor{Floor(3)},
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{},
+				position:     Floor(3),
+				state:        UnloadingAtFloor{Floor(3)},
+				outOfService: true,
+			},
+			wantGivenBack: Order{from: Floor(5), to: Floor(2)},
+		},
+		{
+			name: "transporting-finishes-delivery",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(4)},
+				position:     Floor(3),
+				state:        TransportingPeopleTo{Floor(4)},
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(4)},
+				position:     Floor(3),
+				state:        TransportingPeopleTo{Floor(4)},
+				outOfService: true,
+			},
+			wantGivenBack: Order{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, givenBack := tt.elevator.takeOutOfService()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("takeOutOfService() = \n%+v\n, want \n%+v\n", got, tt.want)
+			}
+			if givenBack != tt.wantGivenBack {
+				t.Errorf("takeOutOfService() given back order = %v, want %v", givenBack, tt.wantGivenBack)
+			}
+		})
+	}
+}
+
+func TestElevator_isIdle(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		want     bool
+	}{
+		{
+			name:     "parked",
+			elevator: Elevator{index: 1, position: 2, state: StopAtFloor{Floor(2)}},
+			want:     true,
+		},
+		{
+			name:     "unloading-last-order",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(1), to: Floor(2)}, position: 2, state: UnloadingAtFloor{Floor(2)}},
+			want:     true,
+		},
+		{
+			name:     "unloading-with-next-order-assigned",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(4), to: Floor(0)}, position: 2, state: UnloadingAtFloor{Floor(2)}},
+			want:     false,
+		},
+		{
+			name:     "out-of-service-delivering",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(4), to: Floor(0)}, position: 2, state: TransportingPeopleTo{Floor(0)}, outOfService: true},
+			want:     false,
+		},
+		{
+			name:     "broken-down-with-people-on-board",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(4), to: Floor(0)}, position: 2, state: TransportingPeopleTo{Floor(0)}, fault: Fault{Kind: Breakdown}},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.isIdle(); got != tt.want {
+				t.Errorf("isIdle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  string
+	}{
+		{
+			name:  "normal",
+			order: Order{from: Floor(1), to: Floor(3)},
+			want:  "[1->3]",
+		},
+		{
+			name:  "vip",
+			order: Order{from: Floor(1), to: Floor(3), priority: VIPPriority},
+			want:  "{1->3}",
+		},
+		{
+			name:  "medical-emergency",
+			order: Order{from: Floor(1), to: Floor(3), priority: MedicalEmergencyPriority},
+			want:  "<1->3>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrders_enqueue(t *testing.T) {
+	normal1 := Order{from: Floor(1), to: Floor(3)}
+	normal2 := Order{from: Floor(2), to: Floor(4)}
+	vip := Order{from: Floor(5), to: Floor(0), priority: VIPPriority}
+	medical := Order{from: Floor(6), to: Floor(0), priority: MedicalEmergencyPriority}
+
+	tests := []struct {
+		name   string
+		orders Orders
+		order  Order
+		want   Orders
+	}{
+		{
+			name:   "empty",
+			orders: Orders{},
+			order:  normal1,
+			want:   Orders{normal1},
+		},
+		{
+			name:   "fifo-within-priority",
+			orders: Orders{vip, normal1},
+			order:  normal2,
+			want:   Orders{vip, normal1, normal2},
+		},
+		{
+			name:   "jumps-lower-priorities",
+			orders: Orders{vip, normal1, normal2},
+			order:  medical,
+			want:   Orders{medical, vip, normal1, normal2},
+		},
+		{
+			name:   "behind-higher-priorities",
+			orders: Orders{medical, normal1},
+			order:  vip,
+			want:   Orders{medical, vip, normal1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.orders.enqueue(tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("enqueue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrders_requeue(t *testing.T) {
+	normal1 := Order{from: Floor(1), to: Floor(3)}
+	normal2 := Order{from: Floor(2), to: Floor(4)}
+	vip := Order{from: Floor(5), to: Floor(0), priority: VIPPriority}
+
+	if got := (Orders{vip, normal1}).requeue(normal2); !reflect.DeepEqual(got, Orders{vip, normal2, normal1}) {
+		t.Errorf("requeue() = %v, want head of normal orders", got)
+	}
+	if got := (Orders{normal1}).requeue(vip); !reflect.DeepEqual(got, Orders{vip, normal1}) {
+		t.Errorf("requeue() = %v, want head of the buffer", got)
+	}
+}
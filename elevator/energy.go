@@ -0,0 +1,96 @@
+package elevator
+
+import "math"
+
+// EnergyModel gives the consumption of an elevator in kWh. Moving down loaded recovers
+// RegenerativeRecovery (between 0 and 1) of the extra energy spent to lift the load
+type EnergyModel struct {
+	UpKWhPerFloor          float64
+	DownKWhPerFloor        float64
+	LoadedExtraKWhPerFloor float64
+	StandbyKWhPerTick      float64
+	RegenerativeRecovery   float64
+}
+
+var DefaultEnergyModel = EnergyModel{
+	UpKWhPerFloor:          0.05,
+	DownKWhPerFloor:        0.02,
+	LoadedExtraKWhPerFloor: 0.03,
+	StandbyKWhPerTick:      0.005,
+	RegenerativeRecovery:   0,
+}
+
+func WithEnergyModel(model EnergyModel) ElevatorOption {
+	return func(e *Elevator) {
+		e.energyModel = model
+	}
+}
+
+func (m EnergyModel) travel(from Floor, to Floor, loaded bool) float64 {
+	floors := math.Abs(float64(to.toInt() - from.toInt()))
+	if to > from {
+		if loaded {
+			return floors * (m.UpKWhPerFloor + m.LoadedExtraKWhPerFloor)
+		} else {
+			return floors * m.UpKWhPerFloor
+		}
+	} else {
+		if loaded {
+			return floors * (m.DownKWhPerFloor - m.RegenerativeRecovery*m.LoadedExtraKWhPerFloor)
+		} else {
+			return floors * m.DownKWhPerFloor
+		}
+	}
+}
+
+// consumption is the energy spent by the elevator between two ticks
+func (m EnergyModel) consumption(before Elevator, after Elevator) float64 {
+	if before.position != after.position {
+		return m.travel(before.position, after.position, before.isCarryingPeople())
+	} else if _, stopped := after.state.(StopAtFloor); stopped {
+		return m.StandbyKWhPerTick
+	} else {
+		return 0
+	}
+}
+
+// energyToServe estimates the energy needed to reach the pickup floor empty then carry people to their destination
+func (e Elevator) energyToServe(newOrder Order) float64 {
+	return e.energyModel.travel(e.position, newOrder.from, false) + e.energyModel.travel(newOrder.from, newOrder.to, true)
+}
+
+type DispatchMode int
+
+const (
+	TimeDispatch DispatchMode = iota
+	EnergyAwareDispatch
+)
+
+// EnableEnergyAwareDispatch lets the controller pick a slower elevator if it needs less energy
+// and its passengers do not wait more than maxExtraWaitTicks compared to the fastest elevator
+func (c *Controller) EnableEnergyAwareDispatch(maxExtraWaitTicks int) {
+	c.dispatchMode = EnergyAwareDispatch
+	c.maxExtraWaitTicks = maxExtraWaitTicks
+}
+
+func (c *Controller) chooseElevator(sortedElevators []Elevator, newOrder Order, pickupTicks map[int]int) Elevator {
+	chosenElevator := sortedElevators[0]
+	if c.dispatchMode != EnergyAwareDispatch {
+		return chosenElevator
+	}
+
+	maxWait := pickupTicks[chosenElevator.index] + c.maxExtraWaitTicks
+	for _, candidate := range sortedElevators[1:] {
+		if pickupTicks[candidate.index] <= maxWait && candidate.energyToServe(newOrder) < chosenElevator.energyToServe(newOrder) {
+			chosenElevator = candidate
+		}
+	}
+	return chosenElevator
+}
+
+func (c *Controller) recordEnergy(before map[int]Elevator, after map[int]Elevator) {
+	// summed by index, the rounding of the total is the same from one run to the other
+	for _, index := range c.sortedIndexes() {
+		c.metrics.EnergyKWh += after[index].energyModel.consumption(before[index], after[index])
+	}
+}
@@ -0,0 +1,133 @@
+package elevator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnergyModel_travel(t *testing.T) {
+	model := EnergyModel{
+		UpKWhPerFloor:          0.05,
+		DownKWhPerFloor:        0.02,
+		LoadedExtraKWhPerFloor: 0.03,
+		StandbyKWhPerTick:      0.005,
+		RegenerativeRecovery:   0.5,
+	}
+
+	tests := []struct {
+		name   string
+		from   Floor
+		to     Floor
+		loaded bool
+		want   float64
+	}{
+		{
+			name: "up-empty",
+			from: Floor(1),
+			to:   Floor(4),
+			want: 0.15,
+		},
+		{
+			name:   "up-loaded",
+			from:   Floor(1),
+			to:     Floor(4),
+			loaded: true,
+			want:   0.24,
+		},
+		{
+			name: "down-empty",
+			from: Floor(4),
+			to:   Floor(2),
+			want: 0.04,
+		},
+		{
+			name:   "down-loaded-with-regenerative-recovery",
+			from:   Floor(4),
+			to:     Floor(2),
+			loaded: true,
+			want:   0.01,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.travel(tt.from, tt.to, tt.loaded); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("travel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnergyModel_consumption(t *testing.T) {
+	stopped := Elevator{index: 1, position: 2, state: StopAtFloor{Floor(2)}}
+	transporting := Elevator{index: 1, currentOrder: Order{from: Floor(2), to: Floor(5)}, position: 2, state: TransportingPeopleTo{Floor(5)}}
+
+	if got := DefaultEnergyModel.consumption(stopped, stopped.nextState()); got != DefaultEnergyModel.StandbyKWhPerTick {
+		t.Errorf("consumption() at standby = %v, want %v", got, DefaultEnergyModel.StandbyKWhPerTick)
+	}
+	want := DefaultEnergyModel.UpKWhPerFloor + DefaultEnergyModel.LoadedExtraKWhPerFloor
+	if got := DefaultEnergyModel.consumption(transporting, transporting.nextState()); math.Abs(got-want) > 1e-9 {
+		t.Errorf("consumption() moving up loaded = %v, want %v", got, want)
+	}
+}
+
+func TestController_chooseElevator_energyAware(t *testing.T) {
+	cheap := EnergyModel{UpKWhPerFloor: 0.01, DownKWhPerFloor: 0.01}
+	expensive := EnergyModel{UpKWhPerFloor: 0.1, DownKWhPerFloor: 0.1}
+	sortedElevators := []Elevator{
+		{index: 1, position: 3, state: StopAtFloor{Floor(3)}, energyModel: expensive},
+		{index: 2, position: 1, state: StopAtFloor{Floor(1)}, energyModel: cheap},
+		{index: 3, position: 0, state: StopAtFloor{Floor(0)}, energyModel: cheap},
+	}
+	newOrder := Order{from: Floor(4), to: Floor(6)}
+
+	tests := []struct {
+		name              string
+		energyAware       bool
+		maxExtraWaitTicks int
+		want              int
+	}{
+		{
+			name: "time-dispatch-picks-fastest",
+			want: 1,
+		},
+		{
+			name:              "energy-aware-within-extra-wait",
+			energyAware:       true,
+			maxExtraWaitTicks: 2,
+			want:              2,
+		},
+		{
+			name:              "energy-aware-no-extra-wait",
+			energyAware:       true,
+			maxExtraWaitTicks: 0,
+			want:              1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			if tt.energyAware {
+				controller.EnableEnergyAwareDispatch(tt.maxExtraWaitTicks)
+			}
+			if got := controller.chooseElevator(sortedElevators, newOrder, estimatePickupTicks(sortedElevators, newOrder)); got.index != tt.want {
+				t.Errorf("chooseElevator() = elevator n°%d, want n°%d", got.index, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_step_recordsEnergy(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 2)
+
+	for tick := 0; tick < 6; tick++ {
+		controller.step()
+	}
+
+	// standby until the order is dispatched, loading, 2 floors up loaded, unloading
+	want := DefaultEnergyModel.StandbyKWhPerTick + 2*(DefaultEnergyModel.UpKWhPerFloor+DefaultEnergyModel.LoadedExtraKWhPerFloor)
+	if got := controller.Metrics().EnergyKWh; math.Abs(got-want) > 1e-9 {
+		t.Errorf("EnergyKWh = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package elevator
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxEstimateTicks bounds the estimate of a car which may never be free, such as a broken down car
+const maxEstimateTicks = 1000
+
+// Arrival is the estimate of an elevator for a hall call, in ticks from now. The ticks are notYet
+// when the elevator can not serve the call
+type Arrival struct {
+	Elevator      int `json:"elevator"`
+	PickupTicks   int `json:"pickupTicks"`
+	DeliveryTicks int `json:"deliveryTicks"`
+}
+
+// estimateArrival replays the transitions of the car: it finishes its current order, takes the new one
+// from the given tick, picks up the people then delivers them. A car already holding the order goes on serving it
+func (e Elevator) estimateArrival(order Order, firstDispatchTick int) Arrival {
+	arrival := Arrival{Elevator: e.index, PickupTicks: notYet, DeliveryTicks: notYet}
+	if e.outOfService || e.fault.Kind == Breakdown {
+		return arrival
+	}
+	assigned := (Order{}) != order && e.currentOrder == order
+	for tick := 0; tick <= maxEstimateTicks; tick++ {
+		if tick > 0 {
+			previousElevator := e
+			e = e.nextState()
+			if assigned && e.currentOrder == order {
+				if _, loading := e.state.(LoadingAtFloor); loading && arrival.PickupTicks == notYet {
+					arrival.PickupTicks = tick
+				}
+				if _, unloading := e.state.(UnloadingAtFloor); unloading && previousElevator.state != e.state && e.position == order.to {
+					arrival.DeliveryTicks = tick
+					return arrival
+				}
+			}
+		}
+		if !assigned && tick >= firstDispatchTick && e.isReadyFor(order) {
+			newElevator, err := e.addOrder(order)
+			if err != nil {
+				return arrival
+			}
+			e, assigned = newElevator, true
+		}
+	}
+	return arrival
+}
+
+// pickupTicks is the estimate used to rank the elevators when the order is dispatched now
+func (e Elevator) pickupTicks(order Order) int {
+	ticks := e.estimateArrival(order, 0).PickupTicks
+	if ticks == notYet {
+		return math.MaxInt
+	}
+	return ticks
+}
+
+// EstimateArrival tells, for each elevator, how long people calling now from a floor would wait for the car
+// and how long until they get off at their destination. Orders already waiting in the buffer are not accounted for
+func (c *Controller) EstimateArrival(from int, to int) ([]Arrival, error) {
+	order := Order{from: Floor(from), to: Floor(to)}
+	if !c.Building().contains(order.from) || !c.Building().contains(order.to) {
+		return nil, fmt.Errorf("order %s is out of the building floors [%d-%d]", order, c.Building().MinFloor, c.Building().MaxFloor)
+	}
+	if from == to {
+		return nil, fmt.Errorf("order.from %d should NOT be equal to order.to %d", from, to)
+	}
+
+	arrivals := []Arrival{}
+	for _, index := range c.sortedIndexes() {
+		elevator := c.elevators[index]
+		if !elevator.canServe(order) {
+			arrivals = append(arrivals, Arrival{Elevator: index, PickupTicks: notYet, DeliveryTicks: notYet})
+			continue
+		}
+		// an order pushed now is dispatched at the end of the next tick
+		arrivals = append(arrivals, elevator.estimateArrival(order, 1))
+	}
+	return arrivals, nil
+}
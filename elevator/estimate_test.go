@@ -0,0 +1,123 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElevator_estimateArrival(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		newOrder Order
+		want     Arrival
+	}{
+		{
+			name:     "stopped-at-floor",
+			elevator: Elevator{index: 1, position: 2, state: StopAtFloor{Floor(2)}},
+			newOrder: Order{from: Floor(5), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: 5, DeliveryTicks: 10},
+		},
+		{
+			name:     "stopped-at-pickup-floor",
+			elevator: Elevator{index: 1, position: 5, state: StopAtFloor{Floor(5)}},
+			newOrder: Order{from: Floor(5), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: 1, DeliveryTicks: 6},
+		},
+		{
+			name: "transporting-then-unloading",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(3)},
+				position:     2,
+				state:        TransportingPeopleTo{Floor(3)},
+			},
+			newOrder: Order{from: Floor(4), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: 5, DeliveryTicks: 9},
+		},
+		{
+			name: "moving-empty-to-another-pickup",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(3)},
+				position:     1,
+				state:        MovingEmptyTo{Floor(5)},
+			},
+			newOrder: Order{from: Floor(4), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: 12, DeliveryTicks: 16},
+		},
+		{
+			name:     "express",
+			elevator: Elevator{index: 1, position: 0, state: StopAtFloor{Floor(0)}, motion: Motion{Speed: 2}},
+			newOrder: Order{from: Floor(7), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: 6, DeliveryTicks: 11},
+		},
+		{
+			name:     "out-of-service",
+			elevator: Elevator{index: 1, position: 0, state: StopAtFloor{Floor(0)}, outOfService: true},
+			newOrder: Order{from: Floor(7), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: notYet, DeliveryTicks: notYet},
+		},
+		{
+			name:     "broken-down",
+			elevator: Elevator{index: 1, position: 3, state: StopAtFloor{Floor(3)}, fault: Fault{Kind: Breakdown}},
+			newOrder: Order{from: Floor(7), to: Floor(2)},
+			want:     Arrival{Elevator: 1, PickupTicks: notYet, DeliveryTicks: notYet},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.estimateArrival(tt.newOrder, 0); got != tt.want {
+				t.Errorf("estimateArrival() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_EstimateArrival(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.AddElevator(2)
+	controller.AddElevator(3, WithServedFloors(0, 7, 8, 9))
+	controller.elevators[2] = Elevator{index: 2, position: 8, state: StopAtFloor{Floor(8)}, motion: DefaultMotion, outOfService: true}
+	controller.PushOrder(6, 1)
+	controller.step()
+	controller.step()
+
+	got, err := controller.EstimateArrival(0, 4)
+
+	if err != nil {
+		t.Fatalf("EstimateArrival() unexpected error = %v", err)
+	}
+	want := []Arrival{
+		{Elevator: 1, PickupTicks: 17, DeliveryTicks: 23},
+		{Elevator: 2, PickupTicks: notYet, DeliveryTicks: notYet},
+		{Elevator: 3, PickupTicks: notYet, DeliveryTicks: notYet},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("EstimateArrival() = %+v, want %+v", got, want)
+	}
+
+	// the estimate of a busy elevator matches the trip of the order pushed now
+	tick := controller.tick
+	controller.PushOrder(0, 4)
+	for !controller.isOver() {
+		controller.step()
+	}
+	trip := controller.Trips()[1]
+	if trip.PickupTick-tick != want[0].PickupTicks || trip.DeliveryTick-tick != want[0].DeliveryTicks {
+		t.Errorf("trip = %+v, estimated from tick %d: %+v", trip, tick, want[0])
+	}
+}
+
+func TestController_EstimateArrival_failures(t *testing.T) {
+	controller := NewController(0)
+	controller.SetBuilding(Building{MinFloor: 0, MaxFloor: 5})
+	controller.AddElevator(1)
+
+	for _, floors := range [][2]int{{0, 7}, {3, 3}, {-1, 2}} {
+		if _, err := controller.EstimateArrival(floors[0], floors[1]); err == nil {
+			t.Errorf("EstimateArrival(%d, %d) should fail", floors[0], floors[1])
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package elevator
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+type FaultKind int
+
+const (
+	NoFault FaultKind = iota
+	StuckBetweenFloors
+	Breakdown
+	DoorJam
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case StuckBetweenFloors:
+		return "STUCK"
+	case Breakdown:
+		return "BROKEN DOWN"
+	case DoorJam:
+		return "DOOR JAMMED"
+	default:
+		return "NO FAULT"
+	}
+}
+
+func faultKindFromString(kind string) (FaultKind, error) {
+	switch kind {
+	case "stuck":
+		return StuckBetweenFloors, nil
+	case "breakdown":
+		return Breakdown, nil
+	case "doorJam":
+		return DoorJam, nil
+	default:
+		return NoFault, fmt.Errorf("unknown fault kind '%s', expected one of stuck, breakdown, doorJam", kind)
+	}
+}
+
+// Fault blocks an elevator for the remaining ticks. A Breakdown is permanent and ignores ticks.
+// A car StuckBetweenFloors keeps its people on board, a DoorJam holds the doors open at the floor of the car
+type Fault struct {
+	Kind  FaultKind
+	Ticks int
+}
+
+func (f Fault) isActive() bool {
+	return f.Kind != NoFault
+}
+
+func (f Fault) elapse() Fault {
+	if f.Kind == Breakdown {
+		return f
+	} else if f.Ticks > 1 {
+		return Fault{Kind: f.Kind, Ticks: f.Ticks - 1}
+	} else {
+		return Fault{}
+	}
+}
+
+func (f Fault) String() string {
+	if f.Kind == Breakdown {
+		return f.Kind.String()
+	} else {
+		return fmt.Sprintf("%s (%d ticks left)", f.Kind, f.Ticks)
+	}
+}
+
+type scheduledFault struct {
+	tick  int
+	index int
+	fault Fault
+}
+
+type randomFaults struct {
+	random      *rand.Rand
+	probability float64
+}
+
+func (r randomFaults) draw() (Fault, bool) {
+	if r.random.Float64() >= r.probability {
+		return Fault{}, false
+	}
+	kind := FaultKind(1 + r.random.Intn(3))
+	return Fault{Kind: kind, Ticks: 1 + r.random.Intn(5)}, true
+}
+
+func (c *Controller) ScheduleFault(tick int, index int, fault Fault) {
+	c.scheduledFaults = append(c.scheduledFaults, scheduledFault{tick: tick, index: index, fault: fault})
+}
+
+// EnableRandomFaults gives every working elevator the given probability to fail at each tick.
+// The dispatch being deterministic, the same seed with the same orders always replays the same run
+func (c *Controller) EnableRandomFaults(seed int64, probability float64) {
+	c.randomFaults = &randomFaults{random: rand.New(rand.NewSource(seed)), probability: probability}
+}
+
+func (c *Controller) InjectFault(index int, fault Fault) error {
+	elevator, ok := c.elevators[index]
+	if !ok {
+		return fmt.Errorf("there is no elevator n°%d in the system", index)
+	}
+	c.elevators[index] = elevator.withFault(fault)
+	return nil
+}
+
+func (c *Controller) injectFaults() {
+	remainingFaults := []scheduledFault{}
+	for _, scheduled := range c.scheduledFaults {
+		if scheduled.tick <= c.tick {
+			// an elevator removed in the meantime cannot fail anymore
+			_ = c.InjectFault(scheduled.index, scheduled.fault)
+		} else {
+			remainingFaults = append(remainingFaults, scheduled)
+		}
+	}
+	c.scheduledFaults = remainingFaults
+
+	if c.randomFaults != nil {
+		// draw in index order so that a seed always gives the same faults
+		for _, index := range c.sortedIndexes() {
+			elevator := c.elevators[index]
+			if elevator.outOfService || elevator.fault.isActive() {
+				continue
+			}
+			if fault, ok := c.randomFaults.draw(); ok {
+				c.elevators[index] = c.elevators[index].withFault(fault)
+			}
+		}
+	}
+}
+
+// detectFaults hands over the orders of faulty elevators that did not pick people yet,
+// and reports people stranded on board
+func (c *Controller) detectFaults() {
+	for _, index := range c.sortedIndexes() {
+		elevator := c.elevators[index]
+		if !elevator.fault.isActive() {
+			delete(c.detectedFaults, index)
+			continue
+		}
+		if _, alreadyDetected := c.detectedFaults[index]; alreadyDetected {
+			continue
+		}
+		if c.detectedFaults == nil {
+			c.detectedFaults = map[int]Fault{}
+		}
+		c.detectedFaults[index] = elevator.fault
+		c.metrics.Faults++
+
+		newElevator, orderToReassign := elevator.releaseOrder()
+		if elevator.fault.Kind == Breakdown {
+			newElevator.outOfService = true
+			// people on board of a stuck car are delivered once the fault clears, not those of a broken down car
+			c.metrics.StrandedOrders += len(elevator.boardedOrders())
+		}
+		if elevator.fault.Kind == DoorJam && newElevator.isCarryingPeople() {
+			newElevator = c.leaveJammedCar(newElevator)
+		}
+		c.elevators[index] = newElevator
+		if (Order{}) != orderToReassign {
+			c.requeueOrder(orderToReassign)
+			c.metrics.ReassignedOrders++
+		}
+	}
+}
+
+// leaveJammedCar lets the people on board of a car jammed doors open get off at its floor, they wait there for another car
+func (c *Controller) leaveJammedCar(e Elevator) Elevator {
+	for _, order := range e.boardedOrders() {
+		if order.to == e.position {
+			c.updateTrip(order, func(trip *Trip) {
+				trip.DeliveryTick = c.tick
+			})
+			continue
+		}
+		order.from = e.position
+		c.requeueOrder(order)
+		c.metrics.ReassignedOrders++
+	}
+
+	state := State(StopAtFloor{e.position})
+	if _, recalled := e.state.(RecallingTo); recalled {
+		// the car goes on to the recall floor once the doors close
+		state = e.state
+	}
+	newElevator := e.withOrderAndState(Order{}, state)
+	newElevator.riders = nil
+	newElevator.stopping = false
+	return newElevator
+}
+
+// reportStrandedOnBoard counts the people left on board of a car still blocked by a stuck fault when the run stops
+func (c *Controller) reportStrandedOnBoard() {
+	for _, index := range c.sortedIndexes() {
+		elevator := c.elevators[index]
+		if elevator.fault.isActive() && elevator.fault.Kind != Breakdown {
+			c.metrics.StrandedOrders += len(elevator.boardedOrders())
+		}
+	}
+}
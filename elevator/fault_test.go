@@ -0,0 +1,215 @@
+package elevator
+
+import (
+	"github.com/mariomac/gostream/stream"
+	"reflect"
+	"testing"
+)
+
+func TestFault_elapse(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault Fault
+		want  Fault
+	}{
+		{
+			name:  "stuck-countdown",
+			fault: Fault{Kind: StuckBetweenFloors, Ticks: 3},
+			want:  Fault{Kind: StuckBetweenFloors, Ticks: 2},
+		},
+		{
+			name:  "door-jam-cleared",
+			fault: Fault{Kind: DoorJam, Ticks: 1},
+			want:  Fault{},
+		},
+		{
+			name:  "breakdown-is-permanent",
+			fault: Fault{Kind: Breakdown},
+			want:  Fault{Kind: Breakdown},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fault.elapse(); got != tt.want {
+				t.Errorf("elapse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElevator_nextState_faulty(t *testing.T) {
+	elevator := Elevator{
+		index:        1,
+		currentOrder: Order{from: Floor(1), to: Floor(5)},
+		position:     2,
+		state:        TransportingPeopleTo{Floor(5)},
+		fault:        Fault{Kind: StuckBetweenFloors, Ticks: 2},
+	}
+
+	stuck := elevator.nextState()
+	want := elevator
+	want.fault = Fault{Kind: StuckBetweenFloors, Ticks: 1}
+	if !reflect.DeepEqual(stuck, want) {
+		t.Errorf("nextState() = \n%+v\n, want \n%+v\n", stuck, want)
+	}
+
+	released := stuck.nextState()
+	if released.fault.isActive() || released.position != Floor(2) {
+		t.Errorf("nextState() = \n%+v\n, want fault cleared at floor 2", released)
+	}
+
+	moving := released.nextState()
+	if moving.position != Floor(3) {
+		t.Errorf("nextState() position = %v, want 3 once the fault is cleared", moving.position)
+	}
+}
+
+func TestController_detectFaults(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     3,
+				state:        MovingEmptyTo{Floor(5)},
+			},
+			2: {
+				index:        2,
+				currentOrder: Order{from: Floor(1), to: Floor(4)},
+				position:     2,
+				state:        TransportingPeopleTo{Floor(4)},
+			},
+		},
+		ordersBuffer: Orders{Order{from: Floor(0), to: Floor(6)}},
+	}
+
+	controller.InjectFault(1, Fault{Kind: StuckBetweenFloors, Ticks: 4})
+	controller.InjectFault(2, Fault{Kind: Breakdown})
+	controller.detectFaults()
+	// a fault is only reported once
+	controller.detectFaults()
+
+	wantBuffer := Orders{Order{from: Floor(5), to: Floor(2)}, Order{from: Floor(0), to: Floor(6)}}
+	if !reflect.DeepEqual(controller.ordersBuffer, wantBuffer) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, wantBuffer)
+	}
+	wantMetrics := Metrics{Faults: 2, ReassignedOrders: 1, StrandedOrders: 1}
+	if controller.Metrics() != wantMetrics {
+		t.Errorf("Metrics() = %+v, wanted %+v", controller.Metrics(), wantMetrics)
+	}
+	if !controller.elevators[2].outOfService || !controller.elevators[2].isIdle() {
+		t.Errorf("broken down elevator should be out of service and idle, got %+v", controller.elevators[2])
+	}
+	if got := (LineRenderer{}).elevatorLine(controller.elevators[2].snapshot()); got != "2 [1->4](TransportingPeopleTo): _  _ |☺⟩ _ ❲4❳  ⚠ BROKEN DOWN, people STRANDED on board" {
+		t.Errorf("elevatorLine() = %v", got)
+	}
+}
+
+func TestController_detectFaults_doorJam(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(5), id: 1},
+				position:     3,
+				progress:     0.5,
+				state:        TransportingPeopleTo{Floor(5)},
+			},
+		},
+		ordersBuffer: Orders{},
+	}
+
+	controller.InjectFault(1, Fault{Kind: DoorJam, Ticks: 2})
+	controller.detectFaults()
+
+	// the people get off at the jammed floor and wait there for another car
+	wantElevator := Elevator{index: 1, position: 3, state: StopAtFloor{Floor(3)}, fault: Fault{Kind: DoorJam, Ticks: 2}}
+	if !reflect.DeepEqual(controller.elevators[1], wantElevator) {
+		t.Errorf("elevator = \n%+v\n, wanted \n%+v\n", controller.elevators[1], wantElevator)
+	}
+	if want := (Orders{{from: Floor(3), to: Floor(5), id: 1}}); !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = %+v, wanted %+v", controller.ordersBuffer, want)
+	}
+	if want := (Metrics{Faults: 1, ReassignedOrders: 1}); controller.Metrics() != want {
+		t.Errorf("Metrics() = %+v, wanted %+v", controller.Metrics(), want)
+	}
+}
+
+func TestController_Record_strandedOnBoard(t *testing.T) {
+	tests := []struct {
+		name         string
+		faultTicks   int
+		wantStranded int
+	}{
+		{
+			name:       "delivered-once-the-fault-clears",
+			faultTicks: 2,
+		},
+		{
+			name:         "still-stuck-when-the-run-stops",
+			faultTicks:   50,
+			wantStranded: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.PushOrder(0, 5)
+			controller.ScheduleFault(4, 1, Fault{Kind: StuckBetweenFloors, Ticks: tt.faultTicks})
+
+			controller.Record(20)
+
+			if got := controller.Metrics().StrandedOrders; got != tt.wantStranded {
+				t.Errorf("StrandedOrders = %d, want %d", got, tt.wantStranded)
+			}
+		})
+	}
+}
+
+func TestController_EnableRandomFaults(t *testing.T) {
+	simulate := func() []Fault {
+		controller := NewController(0)
+		controller.AddElevator(1)
+		controller.AddElevator(2)
+		controller.EnableRandomFaults(42, 0.5)
+		faults := []Fault{}
+		for tick := 0; tick < 10; tick++ {
+			controller.injectFaults()
+			faults = append(faults, controller.elevators[1].fault, controller.elevators[2].fault)
+			controller.step()
+		}
+		return faults
+	}
+
+	first := simulate()
+	if !reflect.DeepEqual(first, simulate()) {
+		t.Errorf("the same seed should inject the same faults")
+	}
+	if !stream.OfSlice(first).AnyMatch(Fault.isActive) {
+		t.Errorf("a probability of 0.5 should inject at least one fault in 10 ticks")
+	}
+}
+
+func TestController_EnableRandomFaults_replaysTheRun(t *testing.T) {
+	simulate := func() ([]Trip, Metrics) {
+		controller := NewController(0)
+		controller.AddElevator(1)
+		controller.AddElevator(2)
+		controller.AddElevator(3)
+		controller.EnableRandomFaults(7, 0.1)
+		for _, order := range [][2]int{{0, 5}, {0, 7}, {3, 1}, {9, 0}, {2, 6}, {6, 2}} {
+			controller.PushOrder(order[0], order[1])
+		}
+		controller.Record(200)
+		return controller.Trips(), controller.Metrics()
+	}
+
+	firstTrips, firstMetrics := simulate()
+	for run := 0; run < 5; run++ {
+		trips, metrics := simulate()
+		if !reflect.DeepEqual(trips, firstTrips) || metrics != firstMetrics {
+			t.Fatalf("the same seed should replay the same run, got \n%+v %+v\n, wanted \n%+v %+v\n", trips, metrics, firstTrips, firstMetrics)
+		}
+	}
+}
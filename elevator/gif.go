@@ -0,0 +1,198 @@
+package elevator
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+)
+
+const (
+	gifFloorHeight  = 20
+	gifShaftWidth   = 28
+	gifLabelsWidth  = 20
+	gifPeopleWidth  = 70
+	gifMargin       = 6
+	gifProgressSize = 4
+	// delay between 2 ticks, in 100th of second
+	gifTickDelay = 50
+)
+
+var gifPalette = color.Palette{
+	color.RGBA{R: 20, G: 20, B: 28, A: 255},    // background
+	color.RGBA{R: 90, G: 90, B: 100, A: 255},   // walls and floors
+	color.RGBA{R: 230, G: 230, B: 230, A: 255}, // stopped elevator and floor numbers
+	color.RGBA{R: 60, G: 180, B: 75, A: 255},   // loading
+	color.RGBA{R: 150, G: 150, B: 150, A: 255}, // moving empty or repositioning
+	color.RGBA{R: 60, G: 110, B: 230, A: 255},  // transporting
+	color.RGBA{R: 220, G: 50, B: 50, A: 255},   // recall, fault and out of service
+	color.RGBA{R: 245, G: 150, B: 40, A: 255},  // people waiting
+	color.RGBA{R: 240, G: 220, B: 60, A: 255},  // destination
+	color.RGBA{R: 70, G: 200, B: 220, A: 255},  // unloading
+}
+
+const (
+	gifBackground uint8 = iota
+	gifWall
+	gifWhite
+	gifGreen
+	gifGrey
+	gifBlue
+	gifRed
+	gifOrange
+	gifYellow
+	gifCyan
+)
+
+// gifDigits are the floor numbers in a 3x5 pixels font, one string per row
+var gifDigits = [10][5]string{
+	{"###", "#.#", "#.#", "#.#", "###"},
+	{".#.", "##.", ".#.", ".#.", "###"},
+	{"###", "..#", "###", "#..", "###"},
+	{"###", "..#", "###", "..#", "###"},
+	{"#.#", "#.#", "###", "..#", "..#"},
+	{"###", "#..", "###", "..#", "###"},
+	{"###", "#..", "###", "#.#", "###"},
+	{"###", "..#", ".#.", ".#.", ".#."},
+	{"###", "#.#", "###", "#.#", "###"},
+	{"###", "#.#", "###", "..#", "###"},
+}
+
+// EncodeGIF draws every snapshot as a frame of an animated GIF, with the meaning of the ASCII pictograms:
+// elevators in their shafts coloured by state, people waiting as orange dots and destinations as yellow squares
+func EncodeGIF(out io.Writer, snapshots []Snapshot) error {
+	animation := &gif.GIF{}
+	for i, snapshot := range snapshots {
+		animation.Image = append(animation.Image, gifFrame(snapshot, i, len(snapshots)))
+		animation.Delay = append(animation.Delay, gifTickDelay)
+	}
+	return gif.EncodeAll(out, animation)
+}
+
+type gifCanvas struct {
+	*image.Paletted
+}
+
+func (c gifCanvas) fill(x0 int, y0 int, x1 int, y1 int, colour uint8) {
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			c.SetColorIndex(x, y, colour)
+		}
+	}
+}
+
+func (c gifCanvas) outline(x0 int, y0 int, x1 int, y1 int, colour uint8) {
+	c.fill(x0, y0, x1, y0+1, colour)
+	c.fill(x0, y1-1, x1, y1, colour)
+	c.fill(x0, y0, x0+1, y1, colour)
+	c.fill(x1-1, y0, x1, y1, colour)
+}
+
+func (c gifCanvas) digit(x int, y int, digit int, colour uint8) {
+	for row, pixels := range gifDigits[digit] {
+		for column, pixel := range pixels {
+			if pixel == '#' {
+				c.fill(x+2*column, y+2*row, x+2*column+2, y+2*row+2, colour)
+			}
+		}
+	}
+}
+
+// arrow is a triangle pointing up or down, centered on x
+func (c gifCanvas) arrow(x int, y int, up bool, colour uint8) {
+	for row := 0; row < 4; row++ {
+		width := row
+		if !up {
+			width = 3 - row
+		}
+		c.fill(x-width, y+row, x+width+1, y+row+1, colour)
+	}
+}
+
+func gifFrame(snapshot Snapshot, frame int, frames int) *image.Paletted {
+	floors := snapshot.MaxFloor - snapshot.MinFloor + 1
+	shaftsLeft := gifMargin + gifLabelsWidth
+	peopleLeft := shaftsLeft + len(snapshot.Elevators)*gifShaftWidth + gifMargin
+	width := peopleLeft + gifPeopleWidth + gifMargin
+	height := gifMargin + floors*gifFloorHeight + gifMargin + gifProgressSize
+
+	canvas := gifCanvas{image.NewPaletted(image.Rect(0, 0, width, height), gifPalette)}
+	floorTop := func(floor int) int {
+		return gifMargin + (snapshot.MaxFloor-floor)*gifFloorHeight
+	}
+
+	for floor := snapshot.MinFloor; floor <= snapshot.MaxFloor; floor++ {
+		top := floorTop(floor)
+		labelColour := gifWhite
+		if snapshot.FireRecall && floor == snapshot.RecallFloor {
+			labelColour = gifRed
+		}
+		canvas.digit(gifMargin, top+5, floor, labelColour)
+		canvas.fill(shaftsLeft, top+gifFloorHeight-1, peopleLeft-gifMargin, top+gifFloorHeight, gifWall)
+	}
+	for i := 0; i <= len(snapshot.Elevators); i++ {
+		x := shaftsLeft + i*gifShaftWidth
+		canvas.fill(x, gifMargin, x+1, gifMargin+floors*gifFloorHeight, gifWall)
+	}
+
+	for i, elevator := range snapshot.Elevators {
+		gifElevator(canvas, elevator, shaftsLeft+i*gifShaftWidth, floorTop(elevator.Position))
+	}
+
+	waiting, destinations := snapshot.people()
+	for floor, orders := range waiting {
+		for i := 0; i < len(orders) && i < 6; i++ {
+			x := peopleLeft + i*8
+			canvas.fill(x, floorTop(floor)+7, x+5, floorTop(floor)+12, gifOrange)
+		}
+	}
+	for floor := range destinations {
+		x := peopleLeft + gifPeopleWidth - 12
+		canvas.outline(x, floorTop(floor)+5, x+10, floorTop(floor)+15, gifYellow)
+	}
+
+	if frames > 1 {
+		progress := (width - 2*gifMargin) * frame / (frames - 1)
+		canvas.fill(gifMargin, height-gifProgressSize-1, gifMargin+progress, height-1, gifGrey)
+	}
+	return canvas.Paletted
+}
+
+func gifElevator(canvas gifCanvas, elevator ElevatorSnapshot, left int, top int) {
+	x0, y0, x1, y1 := left+4, top+3, left+gifShaftWidth-3, top+gifFloorHeight-3
+
+	colour := gifWhite
+	switch elevator.State {
+	case "LoadingAtFloor":
+		colour = gifGreen
+	case "UnloadingAtFloor":
+		colour = gifCyan
+	case "MovingEmptyTo", "RepositioningTo":
+		colour = gifGrey
+	case "TransportingPeopleTo":
+		colour = gifBlue
+	case "RecallingTo":
+		colour = gifRed
+	}
+	if elevator.Fault != "" {
+		colour = gifRed
+	}
+
+	if elevator.State == "StopAtFloor" && elevator.Fault == "" {
+		canvas.outline(x0, y0, x1, y1, colour)
+	} else {
+		canvas.fill(x0, y0, x1, y1, colour)
+	}
+
+	if elevator.Position != elevator.Target && elevator.Fault == "" {
+		canvas.arrow((x0+x1)/2, (y0+y1)/2-2, elevator.Position < elevator.Target, gifBackground)
+	}
+
+	if elevator.OutOfService {
+		for i := 0; i < y1-y0; i++ {
+			x := x0 + i*(x1-x0)/(y1-y0)
+			canvas.SetColorIndex(x, y0+i, gifRed)
+			canvas.SetColorIndex(x1-1-(x-x0), y0+i, gifRed)
+		}
+	}
+}
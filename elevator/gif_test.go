@@ -0,0 +1,85 @@
+package elevator
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+)
+
+func TestController_Record(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 2)
+
+	snapshots := controller.Record(100)
+
+	if len(snapshots) != 7 {
+		t.Fatalf("Record() gave %d snapshots, want 7", len(snapshots))
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.Tick != i {
+			t.Errorf("snapshot %d is at tick %d", i, snapshot.Tick)
+		}
+	}
+	if last := snapshots[len(snapshots)-1].Elevators[0]; last.State != "UnloadingAtFloor" || last.Position != 2 {
+		t.Errorf("last snapshot = %+v, want elevator unloading at floor 2", last)
+	}
+}
+
+func TestController_Record_maxTicks(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 9)
+
+	if snapshots := controller.Record(3); len(snapshots) != 4 {
+		t.Errorf("Record() gave %d snapshots, want 4", len(snapshots))
+	}
+}
+
+func TestEncodeGIF(t *testing.T) {
+	snapshot := Snapshot{
+		MaxFloor: 9,
+		Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 2, State: "LoadingAtFloor", Target: 2, Order: &OrderSnapshot{From: 2, To: 5}, Boarded: true},
+			{Index: 2, Position: 6, State: "MovingEmptyTo", Target: 8, Order: &OrderSnapshot{From: 8, To: 0}, WaitingPickup: true},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	if err := EncodeGIF(out, []Snapshot{snapshot, snapshot}); err != nil {
+		t.Fatalf("EncodeGIF() error = %v", err)
+	}
+
+	animation, err := gif.DecodeAll(out)
+	if err != nil {
+		t.Fatalf("the GIF cannot be decoded: %v", err)
+	}
+	if len(animation.Image) != 2 || animation.Delay[0] != gifTickDelay {
+		t.Fatalf("got %d frames with a delay of %v", len(animation.Image), animation.Delay)
+	}
+
+	frame := animation.Image[0]
+	shaftsLeft := gifMargin + gifLabelsWidth
+	peopleLeft := shaftsLeft + 2*gifShaftWidth + gifMargin
+	floorTop := func(floor int) int {
+		return gifMargin + (9-floor)*gifFloorHeight
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want uint8
+	}{
+		{name: "loading-elevator", x: shaftsLeft + 5, y: floorTop(2) + 4, want: gifGreen},
+		{name: "moving-empty-elevator", x: shaftsLeft + gifShaftWidth + 5, y: floorTop(6) + 4, want: gifGrey},
+		{name: "people-waiting", x: peopleLeft + 1, y: floorTop(8) + 8, want: gifOrange},
+		{name: "destination", x: peopleLeft + gifPeopleWidth - 12, y: floorTop(5) + 8, want: gifYellow},
+		{name: "empty-shaft", x: shaftsLeft + 5, y: floorTop(7) + 4, want: gifBackground},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frame.ColorIndexAt(tt.x, tt.y); got != tt.want {
+				t.Errorf("colour at (%d, %d) = %d, want %d", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package elevator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Violation is an invariant of the state machine broken by an elevator at a given tick
+type Violation struct {
+	Tick      int
+	Elevator  int
+	Invariant string
+	Detail    string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("tick %d, elevator n°%d: %s (%s)", v.Tick, v.Elevator, v.Invariant, v.Detail)
+}
+
+// EnableInvariantChecks verifies the invariants of the state machine after every tick, see Violations
+func (c *Controller) EnableInvariantChecks() {
+	c.checkInvariants = true
+}
+
+func (c *Controller) Violations() []Violation {
+	return c.violations
+}
+
+func (c *Controller) violation(index int, invariant string, detail string) {
+	violation := Violation{Tick: c.tick, Elevator: index, Invariant: invariant, Detail: detail}
+	c.violations = append(c.violations, violation)
+	c.log().Error("invariant violated", "tick", violation.Tick, "elevator", violation.Elevator, "invariant", violation.Invariant, "detail", violation.Detail)
+}
+
+// maxFloorsPerTick is the most floors the car can travel in a tick, the progress left from the last tick included
+func (e Elevator) maxFloorsPerTick() int {
+	return int(math.Max(1, math.Ceil(e.motion.cruiseSpeed())))
+}
+
+// checkTransitions verifies the elevators right after their transition, before new orders change their current order
+func (c *Controller) checkTransitions(before map[int]Elevator, after map[int]Elevator) {
+	for _, index := range c.sortedIndexes() {
+		previousElevator, newElevator := before[index], after[index]
+		position := newElevator.position.toInt()
+
+		if !c.Building().contains(newElevator.position) {
+			c.violation(index, "position within the building", fmt.Sprintf("floor %d out of bound [%d-%d]", position, c.Building().MinFloor, c.Building().MaxFloor))
+		}
+
+		if floors := int(math.Abs(float64(position - previousElevator.position.toInt()))); floors > newElevator.maxFloorsPerTick() {
+			c.violation(index, "speed limit", fmt.Sprintf("moved %d floors from floor %d to floor %d, at most %d per tick", floors, previousElevator.position.toInt(), position, newElevator.maxFloorsPerTick()))
+		}
+
+		switch newElevator.state.(type) {
+		case LoadingAtFloor:
+			if newElevator.position != newElevator.currentOrder.from {
+				c.violation(index, "loading at the pickup floor", fmt.Sprintf("loading %s at floor %d", newElevator.currentOrder, position))
+			}
+		case UnloadingAtFloor:
+			// an elevator still unloading may already have its next order
+			if _, unloading := previousElevator.state.(UnloadingAtFloor); !unloading && newElevator.position != newElevator.currentOrder.to {
+				c.violation(index, "unloading at the destination floor", fmt.Sprintf("unloading %s at floor %d", newElevator.currentOrder, position))
+			}
+		}
+	}
+}
+
+// checkAssignments verifies that an order is either waiting in the buffer or assigned to a single elevator
+func (c *Controller) checkAssignments() {
+	assignedTo := map[int]int{}
+	for _, index := range c.sortedIndexes() {
+		for _, order := range append(Orders{c.elevators[index].currentOrder}, c.elevators[index].riders...) {
+			if order.id == 0 {
+				continue
+			}
+			if otherIndex, assigned := assignedTo[order.id]; assigned {
+				c.violation(index, "order assigned to a single elevator", fmt.Sprintf("order %s n°%d also assigned to elevator n°%d", order, order.id, otherIndex))
+			}
+			assignedTo[order.id] = index
+		}
+	}
+
+	for _, order := range c.ordersBuffer {
+		if index, assigned := assignedTo[order.id]; assigned && order.id != 0 {
+			c.violation(index, "order assigned to a single elevator", fmt.Sprintf("order %s n°%d is still in the orders buffer", order, order.id))
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestController_checkTransitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		before Elevator
+		after  Elevator
+		want   []string
+	}{
+		{
+			name:   "valid-move",
+			before: Elevator{index: 1, position: 2, state: MovingEmptyTo{Floor(5)}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(5)}, motion: DefaultMotion},
+			want:   []string{},
+		},
+		{
+			name:   "out-of-bound",
+			before: Elevator{index: 1, position: 9, state: MovingEmptyTo{Floor(10)}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 10, state: MovingEmptyTo{Floor(10)}, motion: DefaultMotion},
+			want:   []string{"position within the building"},
+		},
+		{
+			name:   "too-fast",
+			before: Elevator{index: 1, position: 0, state: MovingEmptyTo{Floor(5)}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(5)}, motion: DefaultMotion},
+			want:   []string{"speed limit"},
+		},
+		{
+			name:   "loading-elsewhere",
+			before: Elevator{index: 1, position: 3, state: MovingEmptyTo{Floor(3)}, currentOrder: Order{from: 5, to: 1}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 3, state: LoadingAtFloor{Floor(3)}, currentOrder: Order{from: 5, to: 1}, motion: DefaultMotion},
+			want:   []string{"loading at the pickup floor"},
+		},
+		{
+			name:   "unloading-elsewhere",
+			before: Elevator{index: 1, position: 3, state: TransportingPeopleTo{Floor(3)}, currentOrder: Order{from: 5, to: 1}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 3, state: UnloadingAtFloor{Floor(3)}, currentOrder: Order{from: 5, to: 1}, motion: DefaultMotion},
+			want:   []string{"unloading at the destination floor"},
+		},
+		{
+			name:   "still-unloading-with-next-order",
+			before: Elevator{index: 1, position: 3, state: UnloadingAtFloor{Floor(3)}, currentOrder: Order{from: 6, to: 8}, motion: DefaultMotion},
+			after:  Elevator{index: 1, position: 3, state: UnloadingAtFloor{Floor(3)}, currentOrder: Order{from: 6, to: 8}, motion: DefaultMotion},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.tick = 7
+
+			controller.checkTransitions(map[int]Elevator{1: tt.before}, map[int]Elevator{1: tt.after})
+
+			got := []string{}
+			for _, violation := range controller.Violations() {
+				if violation.Tick != 7 || violation.Elevator != 1 {
+					t.Errorf("violation %s should be reported at tick 7 on elevator n°1", violation)
+				}
+				got = append(got, violation.Invariant)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkTransitions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_checkAssignments(t *testing.T) {
+	tests := []struct {
+		name      string
+		elevators map[int]Elevator
+		buffer    Orders
+		want      []Violation
+	}{
+		{
+			name: "single-assignment",
+			elevators: map[int]Elevator{
+				1: {index: 1, currentOrder: Order{from: 1, to: 2, id: 1}},
+				2: {index: 2, currentOrder: Order{from: 3, to: 4, id: 2}},
+			},
+			buffer: Orders{{from: 5, to: 6, id: 3}},
+			want:   nil,
+		},
+		{
+			name: "same-order-in-two-elevators",
+			elevators: map[int]Elevator{
+				1: {index: 1, currentOrder: Order{from: 1, to: 2, id: 1}},
+				2: {index: 2, currentOrder: Order{from: 1, to: 2, id: 1}},
+			},
+			want: []Violation{{Tick: 3, Elevator: 2, Invariant: "order assigned to a single elevator", Detail: "order [1->2] n°1 also assigned to elevator n°1"}},
+		},
+		{
+			name: "assigned-and-buffered",
+			elevators: map[int]Elevator{
+				1: {index: 1, currentOrder: Order{from: 1, to: 2, id: 1}},
+			},
+			buffer: Orders{{from: 1, to: 2, id: 1}},
+			want:   []Violation{{Tick: 3, Elevator: 1, Invariant: "order assigned to a single elevator", Detail: "order [1->2] n°1 is still in the orders buffer"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.elevators = tt.elevators
+			controller.ordersBuffer = tt.buffer
+			controller.tick = 3
+
+			controller.checkAssignments()
+
+			if got := controller.Violations(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkAssignments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_EnableInvariantChecks(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.AddElevator(2)
+	controller.EnableInvariantChecks()
+	controller.PushOrder(0, 5)
+	controller.PushOrder(7, 2)
+	controller.PushOrder(3, 3)
+
+	for i := 0; i < 30; i++ {
+		controller.step()
+	}
+
+	if got := controller.Violations(); len(got) != 0 {
+		t.Errorf("a regular run should not violate invariants, got %v", got)
+	}
+}
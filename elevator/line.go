@@ -0,0 +1,122 @@
+package elevator
+
+import "fmt"
+
+// LineRenderer draws each elevator on its own line, floors from left to right
+type LineRenderer struct {
+	Colours bool
+}
+
+func (l LineRenderer) Render(snapshot Snapshot) string {
+	display := renderHeader(snapshot)
+	for _, elevator := range snapshot.Elevators {
+		display += fmt.Sprintf("%s\n", l.elevatorLine(elevator))
+	}
+	return display
+}
+
+func (l LineRenderer) elevatorLine(e ElevatorSnapshot) string {
+	display := fmt.Sprintf("%d %s", e.Index, l.stateLine(e))
+	if e.Fault != "" {
+		display += fmt.Sprintf("  ⚠ %s", e.Fault)
+		if e.Boarded {
+			display += ", people STRANDED on board"
+		}
+	} else if e.OutOfService {
+		display += "  ✖ OUT OF SERVICE"
+	}
+	if len(e.Riders) > 0 {
+		display += fmt.Sprintf("  ☺ picked up on the way: %v", e.Riders)
+	}
+	if e.Overloaded {
+		display += fmt.Sprintf("  ⚖ OVERLOAD, %d kg boarded, the others wait for the next car", e.Load)
+	}
+	if l.Colours {
+		return colourize(display, stateColour(e))
+	}
+	return display
+}
+
+func (l LineRenderer) stateLine(e ElevatorSnapshot) string {
+	label := "[    ]"
+	// a repositioning elevator interrupted by an order has not started serving it yet
+	if e.Order != nil && e.State != "RepositioningTo" {
+		label = e.Order.String()
+	}
+	display := fmt.Sprintf("%s%-22s:", label, "("+e.State+")")
+
+	pictogram, markers, lastFloor := linePictograms(e)
+	for floor := 0; floor <= lastFloor; floor++ {
+		if floor == e.Position {
+			display += pictogram
+		} else if marker, ok := markers[floor]; ok {
+			display += marker
+		} else {
+			display += " _ "
+		}
+	}
+	return display
+}
+
+// linePictograms gives the pictogram of the elevator, the markers of the floors where people wait or go,
+// and the last floor drawn on the line
+func linePictograms(e ElevatorSnapshot) (string, map[int]string, int) {
+	position := e.Position
+	markers := map[int]string{}
+	lastFloor := position
+
+	var from, to int
+	if e.Order != nil {
+		from, to = e.Order.From, e.Order.To
+	}
+
+	switch e.State {
+	case "TransportingPeopleTo":
+		markers[to] = fmt.Sprintf("❲%d❳", to)
+		return directionPictogram(position, e.Target, "|☺⟩", "⟨☺|", fmt.Sprintf("%d☺%d", position, position)), markers, maxOf(position, from, to)
+	case "MovingEmptyTo":
+		markers[from] = fmt.Sprintf("%d☹%d", from, from)
+		markers[to] = fmt.Sprintf("❲%d❳", to)
+		return directionPictogram(position, e.Target, "|⋅⟩", "⟨⋅|", fmt.Sprintf("⎣%d⎦", position)), markers, maxOf(position, from, to)
+	case "StopAtFloor":
+		if e.Order != nil {
+			markers[from] = fmt.Sprintf("%d☹%d", from, from)
+			markers[to] = fmt.Sprintf("❲%d❳", to)
+			lastFloor = maxOf(position, from, to)
+		}
+		return fmt.Sprintf("⎣%d⎦", position), markers, lastFloor
+	case "LoadingAtFloor":
+		markers[to] = fmt.Sprintf("❲%d❳", to)
+		return fmt.Sprintf("↑%d↑", position), markers, maxOf(position, to)
+	case "UnloadingAtFloor":
+		return fmt.Sprintf("↓%d↓", position), markers, lastFloor
+	case "RecallingTo":
+		markers[e.Target] = fmt.Sprintf("⚑%d⚑", e.Target)
+		return directionPictogram(position, e.Target, "|!⟩", "⟨!|", fmt.Sprintf("⚑%d⚑", position)), markers, maxOf(position, e.Target)
+	case "RepositioningTo":
+		markers[e.Target] = fmt.Sprintf("⌂%d⌂", e.Target)
+		return directionPictogram(position, e.Target, "|⋅⟩", "⟨⋅|", fmt.Sprintf("⌂%d⌂", position)), markers, maxOf(position, e.Target)
+	default:
+		panic(fmt.Sprintf("Unknown state: %s", e.State))
+	}
+}
+
+func directionPictogram(position int, target int, up string, down string, arrived string) string {
+	if position < target {
+		return up
+	} else if position > target {
+		return down
+	} else {
+		return arrived
+	}
+}
+
+func maxOf(first int, others ...int) int {
+	max := first
+	for _, other := range others {
+		if other > max {
+			max = other
+		}
+	}
+	return max
+}
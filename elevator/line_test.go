@@ -2,7 +2,7 @@ package elevator
 
 import "testing"
 
-func TestTransportingPeopleTo_display(t1 *testing.T) {
+func TestLineRenderer_stateLine(t1 *testing.T) {
 
 	tests := []struct {
 		name            string
@@ -222,6 +222,45 @@ func TestTransportingPeopleTo_display(t1 *testing.T) {
 			want:            "[4->1](LoadingAtFloor)      : _ ❲1❳ _  _ ↑4↑",
 		},
 
+		//RecallingTo
+		{
+			name:            "recalling-descending-with-people",
+			currentOrder:    Order{from: Floor(1), to: Floor(4)},
+			currentPosition: 3,
+			currentState:    RecallingTo{Floor(0)},
+			want:            "[1->4](RecallingTo)         :⚑0⚑ _  _ ⟨!|",
+		},
+		{
+			name:            "recalling-ascending-empty",
+			currentOrder:    Order{},
+			currentPosition: 1,
+			currentState:    RecallingTo{Floor(3)},
+			want:            "[    ](RecallingTo)         : _ |!⟩ _ ⚑3⚑",
+		},
+		{
+			name:            "recalling-at-recall-floor",
+			currentOrder:    Order{},
+			currentPosition: 2,
+			currentState:    RecallingTo{Floor(2)},
+			want:            "[    ](RecallingTo)         : _  _ ⚑2⚑",
+		},
+
+		//RepositioningTo
+		{
+			name:            "repositioning-ascending",
+			currentOrder:    Order{},
+			currentPosition: 1,
+			currentState:    RepositioningTo{Floor(4)},
+			want:            "[    ](RepositioningTo)     : _ |⋅⟩ _  _ ⌂4⌂",
+		},
+		{
+			name:            "repositioning-descending",
+			currentOrder:    Order{},
+			currentPosition: 3,
+			currentState:    RepositioningTo{Floor(0)},
+			want:            "[    ](RepositioningTo)     :⌂0⌂ _  _ ⟨⋅|",
+		},
+
 		//UnloadingAtFloor
 		{
 			name:            "unloading-at-floor",
@@ -234,8 +273,36 @@ func TestTransportingPeopleTo_display(t1 *testing.T) {
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
 
-			if got := tt.currentState.display(tt.currentOrder, tt.currentPosition); got != tt.want {
-				t1.Errorf("display() = \n%v\n, but wanted = \n%v\n", got, tt.want)
+			elevator := Elevator{currentOrder: tt.currentOrder, position: floorFromInt(tt.currentPosition), state: tt.currentState}
+			if got := (LineRenderer{}).stateLine(elevator.snapshot()); got != tt.want {
+				t1.Errorf("stateLine() = \n%v\n, but wanted = \n%v\n", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineRenderer_elevatorLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		want     string
+	}{
+		{
+			name: "nominal",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(2), to: Floor(4)},
+				position:     4,
+				state:        UnloadingAtFloor{4},
+			},
+			want: "1 [2->4](UnloadingAtFloor)    : _  _  _  _ ↓4↓",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			if got := (LineRenderer{}).elevatorLine(tt.elevator.snapshot()); got != tt.want {
+				t.Errorf("elevatorLine() = \n%v\n, want \n%v\n", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,83 @@
+package elevator
+
+import "fmt"
+
+// nearFullLoad is the share of the rated load above which a car bypasses hall calls on its way
+const nearFullLoad = 0.8
+
+// WithRatedLoad limits the weight, in kilograms, the car can carry. Without a rated load, the car is never overloaded
+func WithRatedLoad(ratedLoad int) ElevatorOption {
+	return func(e *Elevator) {
+		e.ratedLoad = ratedLoad
+	}
+}
+
+// load is the weight of the people on board
+func (e Elevator) load() int {
+	load := 0
+	for _, rider := range e.riders {
+		load += rider.weight
+	}
+	if e.hasBoardedCurrentOrder() {
+		load += e.currentOrder.weight
+	}
+	return load
+}
+
+func (e Elevator) isNearFullLoad() bool {
+	return e.ratedLoad > 0 && float64(e.load()) >= nearFullLoad*float64(e.ratedLoad)
+}
+
+// canStopFor tells if the car, on its way, has room for the people of a hall call
+func (e Elevator) canStopFor(order Order) bool {
+	return !e.isNearFullLoad() && (e.ratedLoad == 0 || e.load()+order.weight <= e.ratedLoad)
+}
+
+// PushWeightedOrder queues an order with the estimated weight, in kilograms, of the people of the group
+func (c *Controller) PushWeightedOrder(from int, to int, priority Priority, weight int) error {
+	if weight < 0 {
+		return fmt.Errorf("the weight of an order can not be negative: %d", weight)
+	}
+	c.lastOrderID++
+	return c.pushOrder(Order{from: Floor(from), to: Floor(to), priority: priority, weight: weight, id: c.lastOrderID})
+}
+
+// boardWithinRatedLoad lets the people of a loading car board up to its rated load, the others wait for the next car
+// with an order of their own
+func (c *Controller) boardWithinRatedLoad(before map[int]Elevator, after map[int]Elevator) {
+	for _, index := range c.sortedIndexes() {
+		previousElevator, newElevator := before[index], after[index]
+		_, wasLoading := previousElevator.state.(LoadingAtFloor)
+		_, loading := newElevator.state.(LoadingAtFloor)
+		overloaded := loading && !wasLoading && newElevator.ratedLoad > 0 && newElevator.currentOrder.weight > newElevator.ratedLoad
+		newElevator.overloaded = overloaded
+		if overloaded {
+			remainder := newElevator.currentOrder
+			remainder.weight -= newElevator.ratedLoad
+			newElevator.currentOrder.weight = newElevator.ratedLoad
+			c.updateTrip(newElevator.currentOrder, func(trip *Trip) {
+				trip.Weight = newElevator.ratedLoad
+			})
+			c.lastOrderID++
+			remainder.id = c.lastOrderID
+			c.metrics.Overloads++
+			c.log().Warn("overload",
+				"tick", c.tick,
+				"elevator", index,
+				"order", newElevator.currentOrder.String(),
+				"orderId", newElevator.currentOrder.id,
+				"ratedLoad", newElevator.ratedLoad,
+				"remainderId", remainder.id,
+				"remainderWeight", remainder.weight,
+			)
+			// the remainder may be rejected if no other car serves both floors, the simulation goes on
+			_ = c.pushOrder(remainder)
+			// people left behind have been waiting since the group called the car
+			pushTick := c.trips[newElevator.currentOrder.id].PushTick
+			c.updateTrip(remainder, func(trip *Trip) {
+				trip.PushTick = pushTick
+			})
+		}
+		after[index] = newElevator
+	}
+}
@@ -0,0 +1,114 @@
+package elevator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestElevator_canStopFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		order    Order
+		want     bool
+	}{
+		{
+			name:     "no-rated-load",
+			elevator: Elevator{state: TransportingPeopleTo{Floor(5)}, currentOrder: Order{from: 0, to: 5, weight: 900}},
+			order:    Order{from: 2, to: 5, weight: 300},
+			want:     true,
+		},
+		{
+			name:     "room-left",
+			elevator: Elevator{state: TransportingPeopleTo{Floor(5)}, currentOrder: Order{from: 0, to: 5, weight: 300}, ratedLoad: 630},
+			order:    Order{from: 2, to: 5, weight: 200},
+			want:     true,
+		},
+		{
+			name:     "not-enough-room",
+			elevator: Elevator{state: TransportingPeopleTo{Floor(5)}, currentOrder: Order{from: 0, to: 5, weight: 400}, ratedLoad: 630},
+			order:    Order{from: 2, to: 5, weight: 300},
+			want:     false,
+		},
+		{
+			name:     "near-full-load",
+			elevator: Elevator{state: TransportingPeopleTo{Floor(5)}, currentOrder: Order{from: 0, to: 5, weight: 520}, ratedLoad: 630},
+			order:    Order{from: 2, to: 5, weight: 75},
+			want:     false,
+		},
+		{
+			name:     "empty-car",
+			elevator: Elevator{state: MovingEmptyTo{Floor(0)}, currentOrder: Order{from: 0, to: 5, weight: 600}, ratedLoad: 630},
+			order:    Order{from: 2, to: 5, weight: 300},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.canStopFor(tt.order); got != tt.want {
+				t.Errorf("canStopFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_PushWeightedOrder(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+
+	if err := controller.PushWeightedOrder(0, 3, NormalPriority, -10); err == nil {
+		t.Errorf("a negative weight should fail")
+	}
+	if err := controller.PushWeightedOrder(0, 3, VIPPriority, 240); err != nil {
+		t.Errorf("PushWeightedOrder() unexpected error = %v", err)
+	}
+	if want := (Orders{{from: 0, to: 3, priority: VIPPriority, id: 1, weight: 240}}); !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = %+v, want %+v", controller.ordersBuffer, want)
+	}
+	if trip := controller.Trips()[0]; trip.Weight != 240 {
+		t.Errorf("trip weight = %d, want 240", trip.Weight)
+	}
+}
+
+func TestController_boardWithinRatedLoad(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1, WithRatedLoad(630))
+	controller.PushWeightedOrder(0, 4, NormalPriority, 1000)
+
+	controller.step()
+	controller.step()
+
+	elevator := controller.elevators[1]
+	if _, loading := elevator.state.(LoadingAtFloor); !loading || !elevator.overloaded {
+		t.Fatalf("the elevator should signal an overload while loading, got %+v", elevator)
+	}
+	if want := (Order{from: 0, to: 4, id: 1, weight: 630}); elevator.currentOrder != want {
+		t.Errorf("currentOrder = %+v, want %+v", elevator.currentOrder, want)
+	}
+	if want := (Orders{{from: 0, to: 4, id: 2, weight: 370}}); !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = %+v, want the remainder %+v", controller.ordersBuffer, want)
+	}
+	if controller.Metrics().Overloads != 1 {
+		t.Errorf("Overloads = %d, want 1", controller.Metrics().Overloads)
+	}
+	if line := (LineRenderer{}).elevatorLine(elevator.snapshot()); !strings.Contains(line, "⚖ OVERLOAD, 630 kg boarded") {
+		t.Errorf("elevatorLine() = %s", line)
+	}
+
+	controller.step()
+	if controller.elevators[1].overloaded {
+		t.Errorf("the overload signal should stop once the doors close")
+	}
+
+	for !controller.isOver() {
+		controller.step()
+	}
+	trips := controller.Trips()
+	if len(trips) != 2 || !trips[0].Delivered() || !trips[1].Delivered() || trips[0].Weight != 630 || trips[1].Weight != 370 {
+		t.Errorf("both parts of the group should be delivered, got %+v", trips)
+	}
+	if trips[1].PushTick != trips[0].PushTick || trips[1].WaitTicks() <= trips[0].WaitTicks() {
+		t.Errorf("the remainder should wait since the group called the car, got %+v", trips)
+	}
+}
@@ -0,0 +1,106 @@
+package elevator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// NewLogger builds a logger writing at the given level (debug, info, warn or error) in the text or json format
+func NewLogger(out io.Writer, level string, format string) (*slog.Logger, error) {
+	var logLevel slog.Level
+	err := logLevel.UnmarshalText([]byte(level))
+	if err != nil {
+		return nil, fmt.Errorf("unknown log level '%s', expected one of debug, info, warn, error", level)
+	}
+
+	options := &slog.HandlerOptions{Level: logLevel}
+	switch format {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(out, options)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(out, options)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format '%s', expected one of text, json", format)
+	}
+}
+
+// SetLogger logs the transitions of the elevators and the orders left waiting at the debug level,
+// and the dispatch decisions and the rejected orders at the info level
+func (c *Controller) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+func (c *Controller) log() *slog.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+	return c.logger
+}
+
+func (c *Controller) logTransitions(before map[int]Elevator, after map[int]Elevator) {
+	for _, index := range c.sortedIndexes() {
+		previousElevator, newElevator := before[index], after[index]
+		if previousElevator.state == newElevator.state && previousElevator.position == newElevator.position {
+			continue
+		}
+		c.log().Debug("transition",
+			"tick", c.tick,
+			"elevator", index,
+			"from", reflect.TypeOf(previousElevator.state).Name(),
+			"to", reflect.TypeOf(newElevator.state).Name(),
+			"position", newElevator.position.toInt(),
+			"target", newElevator.state.floor().toInt(),
+			"order", newElevator.currentOrder.String(),
+		)
+	}
+}
+
+// dispatchCandidate is an elevator able to take an order, with the scores used to rank it
+type dispatchCandidate struct {
+	Elevator      int    `json:"elevator"`
+	State         string `json:"state"`
+	PickupTicks   int    `json:"pickupTicks"`
+	DeliveryTicks int    `json:"deliveryTicks"`
+}
+
+func (c *Controller) logDispatch(order Order, sortedElevators []Elevator, chosenElevator Elevator, preempted bool) {
+	// estimating the arrival of every candidate is only worth it when someone reads the decision
+	if !c.log().Enabled(context.Background(), slog.LevelInfo) {
+		return
+	}
+	candidates := []dispatchCandidate{}
+	for _, elevator := range sortedElevators {
+		arrival := elevator.estimateArrival(order, 0)
+		candidates = append(candidates, dispatchCandidate{
+			Elevator:      elevator.index,
+			State:         reflect.TypeOf(elevator.state).Name(),
+			PickupTicks:   arrival.PickupTicks,
+			DeliveryTicks: arrival.DeliveryTicks,
+		})
+	}
+	c.log().Info("dispatch",
+		"tick", c.tick,
+		"order", order.String(),
+		"orderId", order.id,
+		"candidates", candidates,
+		"winner", chosenElevator.index,
+		"preempted", preempted,
+	)
+}
+
+// logWaitingOrders lists the orders that no elevator could take during this tick
+func (c *Controller) logWaitingOrders() {
+	if len(c.ordersBuffer) == 0 || !c.log().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	orders := []string{}
+	for _, order := range c.ordersBuffer {
+		orders = append(orders, order.String())
+	}
+	c.log().Debug("waiting", "tick", c.tick, "orders", orders)
+}
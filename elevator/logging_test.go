@@ -0,0 +1,141 @@
+package elevator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		format  string
+		wantErr bool
+	}{
+		{name: "text", level: "info", format: "text"},
+		{name: "json", level: "debug", format: "json"},
+		{name: "default-format", level: "warn", format: ""},
+		{name: "unknown-level", level: "verbose", format: "text", wantErr: true},
+		{name: "unknown-format", level: "info", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLogger(&bytes.Buffer{}, tt.level, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLogger() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func logLines(t *testing.T, out *bytes.Buffer) []map[string]any {
+	lines := []map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %s: %v", line, err)
+		}
+		delete(entry, "time")
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestController_logDispatch(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _ := NewLogger(out, "info", "json")
+	controller := NewController(0)
+	controller.SetLogger(logger)
+	controller.AddElevator(1)
+	controller.AddElevator(2)
+	controller.elevators[2] = Elevator{index: 2, position: 4, state: StopAtFloor{Floor(4)}, motion: DefaultMotion}
+	controller.PushOrder(5, 1)
+
+	controller.step()
+
+	want := []map[string]any{{
+		"level":   "INFO",
+		"msg":     "dispatch",
+		"tick":    float64(1),
+		"order":   "[5->1]",
+		"orderId": float64(1),
+		"candidates": []any{
+			map[string]any{"elevator": float64(2), "state": "StopAtFloor", "pickupTicks": float64(3), "deliveryTicks": float64(9)},
+			map[string]any{"elevator": float64(1), "state": "StopAtFloor", "pickupTicks": float64(7), "deliveryTicks": float64(13)},
+		},
+		"winner":    float64(2),
+		"preempted": false,
+	}}
+	if got := logLines(t, out); !reflect.DeepEqual(got, want) {
+		t.Errorf("logs = %+v, want %+v", got, want)
+	}
+}
+
+func TestController_logRejectedAndWaitingOrders(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _ := NewLogger(out, "debug", "json")
+	controller := NewController(0)
+	controller.SetLogger(logger)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 20)
+	controller.PushOrder(0, 5)
+	controller.PushOrder(3, 1)
+
+	controller.step()
+
+	want := []map[string]any{
+		{"level": "INFO", "msg": "reject", "tick": float64(0), "order": "[0->20]", "orderId": float64(1)},
+		{"level": "DEBUG", "msg": "waiting", "tick": float64(1), "orders": []any{"[3->1]"}},
+	}
+	got := []map[string]any{}
+	for _, line := range logLines(t, out) {
+		if line["msg"] == "reject" || line["msg"] == "waiting" {
+			got = append(got, line)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("logs = %+v, want %+v", got, want)
+	}
+}
+
+func TestController_logDispatch_disabled(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _ := NewLogger(out, "warn", "json")
+	controller := NewController(0)
+	controller.SetLogger(logger)
+	controller.AddElevator(1)
+	controller.PushOrder(5, 1)
+
+	controller.step()
+
+	if out.Len() > 0 {
+		t.Errorf("logs = %s, want nothing below the warn level", out.String())
+	}
+}
+
+func TestController_logTransitions(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger, _ := NewLogger(out, "debug", "json")
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(1, 3)
+	controller.step()
+
+	controller.SetLogger(logger)
+	controller.step()
+	controller.step()
+
+	want := []map[string]any{
+		{"level": "DEBUG", "msg": "transition", "tick": float64(2), "elevator": float64(1), "from": "StopAtFloor", "to": "MovingEmptyTo", "position": float64(0), "target": float64(1), "order": "[1->3]"},
+		{"level": "DEBUG", "msg": "transition", "tick": float64(3), "elevator": float64(1), "from": "MovingEmptyTo", "to": "MovingEmptyTo", "position": float64(1), "target": float64(1), "order": "[1->3]"},
+	}
+	if got := logLines(t, out); !reflect.DeepEqual(got, want) {
+		t.Errorf("logs = %+v, want %+v", got, want)
+	}
+}
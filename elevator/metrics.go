@@ -0,0 +1,35 @@
+package elevator
+
+import "fmt"
+
+type Metrics struct {
+	Faults           int     `json:"faults"`
+	ReassignedOrders int     `json:"reassignedOrders"`
+	StrandedOrders   int     `json:"strandedOrders"`
+	EnergyKWh        float64 `json:"energyKWh"`
+	OverdueOrders    int     `json:"overdueOrders"`
+	CancelledOrders  int     `json:"cancelledOrders"`
+	Overloads        int     `json:"overloads"`
+	PickupsOnTheWay  int     `json:"pickupsOnTheWay"`
+}
+
+func (m Metrics) String() string {
+	return fmt.Sprintf("energy: %.2f kWh, faults: %d, reassigned orders: %d, stranded orders: %d, overdue orders: %d, cancelled orders: %d, overloads: %d, pickups on the way: %d", m.EnergyKWh, m.Faults, m.ReassignedOrders, m.StrandedOrders, m.OverdueOrders, m.CancelledOrders, m.Overloads, m.PickupsOnTheWay)
+}
+
+func (m Metrics) add(other Metrics) Metrics {
+	return Metrics{
+		Faults:           m.Faults + other.Faults,
+		ReassignedOrders: m.ReassignedOrders + other.ReassignedOrders,
+		StrandedOrders:   m.StrandedOrders + other.StrandedOrders,
+		EnergyKWh:        m.EnergyKWh + other.EnergyKWh,
+		OverdueOrders:    m.OverdueOrders + other.OverdueOrders,
+		CancelledOrders:  m.CancelledOrders + other.CancelledOrders,
+		Overloads:        m.Overloads + other.Overloads,
+		PickupsOnTheWay:  m.PickupsOnTheWay + other.PickupsOnTheWay,
+	}
+}
+
+func (c *Controller) Metrics() Metrics {
+	return c.metrics
+}
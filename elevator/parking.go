@@ -0,0 +1,135 @@
+package elevator
+
+import (
+	"fmt"
+	"sort"
+)
+
+// number of recent orders considered by the HighestDemand policy
+const demandWindow = 20
+
+// ParkingPolicy chooses where free elevators wait for the next order, the most useful floors first
+type ParkingPolicy interface {
+	parkingFloors(c *Controller, count int) []Floor
+}
+
+type ReturnToLobby struct {
+	Lobby int
+}
+
+func (r ReturnToLobby) parkingFloors(c *Controller, count int) []Floor {
+	lobby := floorFromInt(r.Lobby)
+	if !c.Building().contains(lobby) {
+		// the default lobby is the ground floor, which a building starting higher does not have
+		lobby = floorFromInt(c.Building().MinFloor)
+	}
+	floors := []Floor{}
+	for i := 0; i < count; i++ {
+		floors = append(floors, lobby)
+	}
+	return floors
+}
+
+// SpreadAcrossZones splits the building in as many zones as free elevators and parks one elevator in the middle of each zone
+type SpreadAcrossZones struct {
+}
+
+func (s SpreadAcrossZones) parkingFloors(c *Controller, count int) []Floor {
+	floors := []Floor{}
+	building := c.Building()
+	floorsCount := building.MaxFloor - building.MinFloor + 1
+	for zone := 0; zone < count; zone++ {
+		zoneStart := building.MinFloor + zone*floorsCount/count
+		zoneEnd := building.MinFloor + (zone+1)*floorsCount/count - 1
+		floors = append(floors, floorFromInt((zoneStart+zoneEnd)/2))
+	}
+	return floors
+}
+
+// HighestDemand parks elevators at the floors where people called elevators the most, among the recent orders
+type HighestDemand struct {
+}
+
+func (h HighestDemand) parkingFloors(c *Controller, count int) []Floor {
+	calls := map[Floor]int{}
+	for _, floor := range c.recentPickups {
+		calls[floor]++
+	}
+
+	floors := []Floor{}
+	for floor := range calls {
+		floors = append(floors, floor)
+	}
+	sort.Slice(floors, func(i, j int) bool {
+		if calls[floors[i]] == calls[floors[j]] {
+			return floors[i] < floors[j]
+		}
+		return calls[floors[i]] > calls[floors[j]]
+	})
+
+	if len(floors) > count {
+		return floors[:count]
+	}
+	return floors
+}
+
+func ParkingPolicyFromString(policy string) (ParkingPolicy, error) {
+	switch policy {
+	case "":
+		return nil, nil
+	case "lobby":
+		return ReturnToLobby{Lobby: minFloor}, nil
+	case "spread":
+		return SpreadAcrossZones{}, nil
+	case "demand":
+		return HighestDemand{}, nil
+	default:
+		return nil, fmt.Errorf("unknown parking policy '%s', expected one of lobby, spread, demand", policy)
+	}
+}
+
+func (c *Controller) SetParkingPolicy(policy ParkingPolicy) {
+	c.parkingPolicy = policy
+}
+
+func (c *Controller) recordPickup(floor Floor) {
+	c.recentPickups = append(c.recentPickups, floor)
+	if len(c.recentPickups) > demandWindow {
+		c.recentPickups = c.recentPickups[len(c.recentPickups)-demandWindow:]
+	}
+}
+
+// parkFreeElevators sends free elevators to the parking floors of the policy, the nearest elevator to each floor
+func (c *Controller) parkFreeElevators() {
+	if c.parkingPolicy == nil || c.mode == FireRecall || len(c.ordersBuffer) > 0 {
+		return
+	}
+
+	freeElevators := []Elevator{}
+	for _, index := range c.sortedIndexes() {
+		elevator := c.elevators[index]
+		_, repositioning := elevator.state.(RepositioningTo)
+		// an elevator repositioning with an order was just interrupted by this order
+		if (elevator.isParked() || repositioning && (Order{}) == elevator.currentOrder) && !elevator.outOfService && !elevator.fault.isActive() {
+			freeElevators = append(freeElevators, elevator)
+		}
+	}
+
+	for _, floor := range c.parkingPolicy.parkingFloors(c, len(freeElevators)) {
+		nearest := -1
+		for i, elevator := range freeElevators {
+			if elevator.serves(floor) && (nearest < 0 || elevator.computeDistance(elevator.position, floor) < freeElevators[nearest].computeDistance(freeElevators[nearest].position, floor)) {
+				nearest = i
+			}
+		}
+		if nearest < 0 {
+			continue
+		}
+
+		elevator := freeElevators[nearest]
+		freeElevators = append(freeElevators[:nearest:nearest], freeElevators[nearest+1:]...)
+		if elevator.position != floor || elevator.state != (StopAtFloor{floor}) {
+			c.elevators[elevator.index] = elevator.withOrderAndState(Order{}, RepositioningTo{floor})
+		}
+	}
+}
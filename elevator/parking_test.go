@@ -0,0 +1,106 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParkingPolicy_parkingFloors(t *testing.T) {
+	controller := &Controller{recentPickups: []Floor{3, 7, 3, 0, 7, 3}}
+
+	tests := []struct {
+		name   string
+		policy ParkingPolicy
+		count  int
+		want   []Floor
+	}{
+		{
+			name:   "return-to-lobby",
+			policy: ReturnToLobby{Lobby: 0},
+			count:  2,
+			want:   []Floor{0, 0},
+		},
+		{
+			name:   "spread-one-elevator",
+			policy: SpreadAcrossZones{},
+			count:  1,
+			want:   []Floor{4},
+		},
+		{
+			name:   "spread-three-elevators",
+			policy: SpreadAcrossZones{},
+			count:  3,
+			want:   []Floor{1, 4, 7},
+		},
+		{
+			name:   "highest-demand",
+			policy: HighestDemand{},
+			count:  2,
+			want:   []Floor{3, 7},
+		},
+		{
+			name:   "highest-demand-fewer-floors-than-elevators",
+			policy: HighestDemand{},
+			count:  5,
+			want:   []Floor{3, 7, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.parkingFloors(controller, tt.count); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parkingFloors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_parkFreeElevators(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {index: 1, position: 6, state: StopAtFloor{Floor(6)}},
+			2: {index: 2, position: 1, state: StopAtFloor{Floor(1)}},
+			3: {index: 3, currentOrder: Order{from: Floor(2), to: Floor(5)}, position: 4, state: TransportingPeopleTo{Floor(5)}},
+			4: {index: 4, position: 0, state: StopAtFloor{Floor(0)}, outOfService: true},
+		},
+		ordersBuffer:  Orders{},
+		parkingPolicy: SpreadAcrossZones{},
+	}
+
+	controller.parkFreeElevators()
+
+	if got := controller.elevators[1].state; got != (RepositioningTo{Floor(7)}) {
+		t.Errorf("elevator n°1 state = %+v, want RepositioningTo{7}", got)
+	}
+	if got := controller.elevators[2].state; got != (RepositioningTo{Floor(2)}) {
+		t.Errorf("elevator n°2 state = %+v, want RepositioningTo{2}", got)
+	}
+	if got := controller.elevators[3].state; got != (TransportingPeopleTo{Floor(5)}) {
+		t.Errorf("busy elevator n°3 should not be parked, state = %+v", got)
+	}
+	if got := controller.elevators[4].state; got != (StopAtFloor{Floor(0)}) {
+		t.Errorf("out of service elevator n°4 should not be parked, state = %+v", got)
+	}
+}
+
+func TestController_parkFreeElevators_interruptedByOrder(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.SetParkingPolicy(ReturnToLobby{Lobby: 9})
+
+	controller.step()
+	controller.step()
+	controller.step()
+	if got := controller.elevators[1]; got.state != (RepositioningTo{Floor(9)}) || got.position != Floor(2) {
+		t.Fatalf("elevator should be moving to the lobby, got %+v", got)
+	}
+
+	controller.PushOrder(1, 5)
+	controller.step()
+	if got := controller.elevators[1].currentOrder; got != (Order{from: Floor(1), to: Floor(5), id: 1}) {
+		t.Errorf("repositioning elevator should take the next order, got %+v", got)
+	}
+	controller.step()
+	if got := controller.elevators[1].state; got != (MovingEmptyTo{Floor(1)}) {
+		t.Errorf("repositioning elevator should move to the pickup floor, got %+v", got)
+	}
+}
@@ -0,0 +1,184 @@
+package elevator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// histogramBuckets are the upper bounds, in ticks, of the wait and ride time histograms
+var histogramBuckets = []int{1, 2, 5, 10, 20, 50, 100}
+
+var stateNames = []string{"StopAtFloor", "MovingEmptyTo", "LoadingAtFloor", "TransportingPeopleTo", "UnloadingAtFloor", "RecallingTo", "RepositioningTo"}
+
+// PrometheusExporter serves the metrics of the controller in the Prometheus text format. The page is
+// refreshed after each tick of the simulation, so scrapes never read the controller while it changes
+type PrometheusExporter struct {
+	mutex sync.Mutex
+	page  string
+	trips *prometheusTrips
+}
+
+func NewPrometheusExporter() *PrometheusExporter {
+	return &PrometheusExporter{trips: newPrometheusTrips()}
+}
+
+func (c *Controller) SetPrometheusExporter(exporter *PrometheusExporter) {
+	c.exporter = exporter
+	c.changedTrips = nil
+	for _, trip := range c.Trips() {
+		c.changedTrips = append(c.changedTrips, trip.ID)
+	}
+	exporter.update(c)
+}
+
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mutex.Lock()
+	page := e.page
+	e.mutex.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+// update counts again the trips changed since the last tick only, the cost of a tick does not grow with the run
+func (e *PrometheusExporter) update(c *Controller) {
+	for _, id := range c.changedTrips {
+		e.trips.update(c.trips[id])
+	}
+	c.changedTrips = nil
+	page := prometheusPage(c.Snapshot(), e.trips)
+	e.mutex.Lock()
+	e.page = page
+	e.mutex.Unlock()
+}
+
+type prometheusWriter struct {
+	strings.Builder
+}
+
+func (w *prometheusWriter) header(name string, kind string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (w *prometheusWriter) metric(name string, kind string, help string, value float64) {
+	w.header(name, kind, help)
+	fmt.Fprintf(w, "%s %g\n", name, value)
+}
+
+func (w *prometheusWriter) histogram(name string, help string, h prometheusHistogram) {
+	w.header(name, "histogram", help)
+	for i, bucket := range histogramBuckets {
+		fmt.Fprintf(w, "%s_bucket{le=\"%d\"} %d\n", name, bucket, h.buckets[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+	fmt.Fprintf(w, "%s_sum %d\n", name, h.sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, h.count)
+}
+
+// prometheusHistogram keeps the cumulative count of the observations of each bucket
+type prometheusHistogram struct {
+	buckets []int
+	sum     int
+	count   int
+}
+
+func newPrometheusHistogram() prometheusHistogram {
+	return prometheusHistogram{buckets: make([]int, len(histogramBuckets))}
+}
+
+// observe adds an observation, or takes it back with a weight of -1
+func (h *prometheusHistogram) observe(value int, weight int) {
+	for i, bucket := range histogramBuckets {
+		if value <= bucket {
+			h.buckets[i] += weight
+		}
+	}
+	h.sum += weight * value
+	h.count += weight
+}
+
+// prometheusTrips sums up the trips. A changed trip takes back what it counted before and counts again
+type prometheusTrips struct {
+	counted   map[int]Trip
+	pushed    int
+	delivered int
+	rejected  int
+	waits     prometheusHistogram
+	rides     prometheusHistogram
+}
+
+func newPrometheusTrips() *prometheusTrips {
+	return &prometheusTrips{counted: map[int]Trip{}, waits: newPrometheusHistogram(), rides: newPrometheusHistogram()}
+}
+
+func (t *prometheusTrips) update(trip Trip) {
+	if previous, ok := t.counted[trip.ID]; ok {
+		t.count(previous, -1)
+	}
+	t.counted[trip.ID] = trip
+	t.count(trip, 1)
+}
+
+func (t *prometheusTrips) count(trip Trip, weight int) {
+	t.pushed += weight
+	if trip.Rejected {
+		t.rejected += weight
+	}
+	if trip.PickupTick != notYet {
+		t.waits.observe(trip.WaitTicks(), weight)
+	}
+	if trip.Delivered() {
+		t.delivered += weight
+		t.rides.observe(trip.RideTicks(), weight)
+	}
+}
+
+func prometheusPage(snapshot Snapshot, trips *prometheusTrips) string {
+	w := &prometheusWriter{}
+
+	w.metric("elevator_tick", "gauge", "Current tick of the simulation.", float64(snapshot.Tick))
+	w.metric("elevator_orders_queue_length", "gauge", "Orders waiting in the buffer for an elevator.", float64(len(snapshot.OrdersBuffer)))
+
+	w.header("elevator_position", "gauge", "Floor of each elevator.")
+	for _, elevator := range snapshot.Elevators {
+		fmt.Fprintf(w, "elevator_position{elevator=\"%d\"} %d\n", elevator.Index, elevator.Position)
+	}
+
+	w.header("elevator_state", "gauge", "State of each elevator, 1 for the current state.")
+	for _, elevator := range snapshot.Elevators {
+		for _, state := range stateNames {
+			value := 0
+			if state == elevator.State {
+				value = 1
+			}
+			fmt.Fprintf(w, "elevator_state{elevator=\"%d\",state=\"%s\"} %d\n", elevator.Index, state, value)
+		}
+	}
+
+	w.header("elevator_out_of_service", "gauge", "1 when the elevator is out of service or faulty.")
+	for _, elevator := range snapshot.Elevators {
+		value := 0
+		if elevator.OutOfService || elevator.Fault != "" {
+			value = 1
+		}
+		fmt.Fprintf(w, "elevator_out_of_service{elevator=\"%d\"} %d\n", elevator.Index, value)
+	}
+
+	w.metric("elevator_orders_pushed_total", "counter", "Orders pushed to the controller.", float64(trips.pushed))
+	w.metric("elevator_orders_delivered_total", "counter", "Orders whose people got off at their destination.", float64(trips.delivered))
+	w.metric("elevator_orders_rejected_total", "counter", "Orders that no elevator in service can serve.", float64(trips.rejected))
+	w.metric("elevator_orders_overdue_total", "counter", "Orders that waited more than the maximum wait for an elevator.", float64(snapshot.Metrics.OverdueOrders))
+	w.metric("elevator_orders_cancelled_total", "counter", "Orders cancelled before people boarded.", float64(snapshot.Metrics.CancelledOrders))
+	w.metric("elevator_overloads_total", "counter", "Loadings where people exceeded the rated load of the car.", float64(snapshot.Metrics.Overloads))
+	w.metric("elevator_pickups_on_the_way_total", "counter", "Orders picked up by a car passing their floor.", float64(snapshot.Metrics.PickupsOnTheWay))
+	w.metric("elevator_faults_total", "counter", "Faults detected on the elevators.", float64(snapshot.Metrics.Faults))
+	// regenerative drives give energy back, the consumption is not a counter
+	w.metric("elevator_energy_kwh", "gauge", "Energy consumed by the elevators since the start.", snapshot.Metrics.EnergyKWh)
+
+	w.histogram("elevator_order_wait_ticks", "Ticks between the push of an order and the pickup of its people.", trips.waits)
+	w.histogram("elevator_order_ride_ticks", "Ticks people spent in the elevator.", trips.rides)
+
+	return w.String()
+}
@@ -0,0 +1,106 @@
+package elevator
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusPage(t *testing.T) {
+	snapshot := Snapshot{
+		Tick:         12,
+		OrdersBuffer: []OrderSnapshot{{ID: 4, From: 2, To: 0}},
+		Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 3, State: "TransportingPeopleTo"},
+			{Index: 2, Position: 0, State: "StopAtFloor", OutOfService: true},
+		},
+		Metrics: Metrics{Faults: 1, EnergyKWh: 0.5},
+	}
+	trips := []Trip{
+		{ID: 1, PushTick: 0, PickupTick: 2, DeliveryTick: 6},
+		{ID: 2, PushTick: 1, PickupTick: 8, DeliveryTick: notYet},
+		{ID: 3, PushTick: 2, PickupTick: notYet, DeliveryTick: notYet, Rejected: true},
+		{ID: 4, PushTick: 5, PickupTick: notYet, DeliveryTick: notYet},
+	}
+
+	counters := newPrometheusTrips()
+	for _, trip := range trips {
+		counters.update(trip)
+	}
+	page := prometheusPage(snapshot, counters)
+
+	for _, want := range []string{
+		"# TYPE elevator_tick gauge\nelevator_tick 12\n",
+		"elevator_orders_queue_length 1\n",
+		"elevator_position{elevator=\"1\"} 3\n",
+		"elevator_state{elevator=\"1\",state=\"TransportingPeopleTo\"} 1\n",
+		"elevator_state{elevator=\"1\",state=\"StopAtFloor\"} 0\n",
+		"elevator_out_of_service{elevator=\"2\"} 1\n",
+		"# TYPE elevator_orders_delivered_total counter\nelevator_orders_delivered_total 1\n",
+		"elevator_orders_rejected_total 1\n",
+		"elevator_orders_pushed_total 4\n",
+		"elevator_energy_kwh 0.5\n",
+		"# TYPE elevator_order_wait_ticks histogram\n" +
+			"elevator_order_wait_ticks_bucket{le=\"1\"} 0\n" +
+			"elevator_order_wait_ticks_bucket{le=\"2\"} 1\n" +
+			"elevator_order_wait_ticks_bucket{le=\"5\"} 1\n" +
+			"elevator_order_wait_ticks_bucket{le=\"10\"} 2\n",
+		"elevator_order_wait_ticks_bucket{le=\"+Inf\"} 2\nelevator_order_wait_ticks_sum 9\nelevator_order_wait_ticks_count 2\n",
+		"elevator_order_ride_ticks_sum 4\nelevator_order_ride_ticks_count 1\n",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("the page should contain:\n%s\ngot:\n%s", want, page)
+		}
+	}
+}
+
+func TestPrometheusExporter_ServeHTTP(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 2)
+	exporter := NewPrometheusExporter()
+	controller.SetPrometheusExporter(exporter)
+
+	controller.step()
+	controller.step()
+
+	response := httptest.NewRecorder()
+	exporter.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+
+	if got := response.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %s", got)
+	}
+	body := response.Body.String()
+	if !strings.Contains(body, "elevator_tick 2\n") || !strings.Contains(body, "elevator_state{elevator=\"1\",state=\"LoadingAtFloor\"} 1\n") {
+		t.Errorf("the metrics should be the ones of tick 2:\n%s", body)
+	}
+}
+
+func TestPrometheusExporter_update_countsChangedTrips(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1, WithRatedLoad(630))
+	controller.AddElevator(2)
+	controller.PushOrder(0, 8)
+	controller.PushOrder(4, 20)
+	exporter := NewPrometheusExporter()
+	controller.SetPrometheusExporter(exporter)
+	controller.PushOrder(3, 6)
+	controller.PushWeightedOrder(9, 1, NormalPriority, 900)
+	controller.PushOrder(5, 0)
+	controller.ScheduleCancellation(2, 5)
+	controller.ScheduleFault(4, 2, Fault{Kind: StuckBetweenFloors, Ticks: 3})
+
+	controller.Record(100)
+
+	// the running counts match a count of all the trips at the end of the run
+	counters := newPrometheusTrips()
+	for _, trip := range controller.Trips() {
+		counters.update(trip)
+	}
+	if want := prometheusPage(controller.Snapshot(), counters); exporter.page != want {
+		t.Errorf("page = \n%s\n, want \n%s\n", exporter.page, want)
+	}
+	if counters.delivered == 0 || counters.rejected == 0 {
+		t.Errorf("the run should deliver and reject orders, got %+v", counters)
+	}
+}
@@ -0,0 +1,89 @@
+package elevator
+
+// SetReassignmentThreshold lets a free elevator take an order nobody boarded yet from another elevator,
+// when it would pick people up more than thresholdTicks earlier. A zero value disables reassignments
+func (c *Controller) SetReassignmentThreshold(thresholdTicks int) {
+	c.reassignmentThreshold = thresholdTicks
+}
+
+// canHandOver tells if the order of the elevator may still go to another elevator, nobody having boarded yet:
+// the elevator moves empty to the pickup, or was given the order while people were getting off
+func (e Elevator) canHandOver() bool {
+	if _, unloading := e.state.(UnloadingAtFloor); unloading {
+		return e.currentOrder.to != e.position && len(e.riders) == 0 && !e.outOfService && !e.fault.isActive()
+	}
+	return e.isMovingEmptyToPickup()
+}
+
+// freeElevators are the elevators able to take a new order, by index
+func (c *Controller) freeElevators() []Elevator {
+	elevators := []Elevator{}
+	for _, index := range c.sortedIndexes() {
+		if c.elevators[index].isReadyForNewOrder() {
+			elevators = append(elevators, c.elevators[index])
+		}
+	}
+	return elevators
+}
+
+// reassignOrders re-evaluates the orders not picked up yet against the elevators free after the dispatch
+func (c *Controller) reassignOrders() {
+	if c.reassignmentThreshold <= 0 || c.mode == FireRecall {
+		return
+	}
+	freeElevators := c.freeElevators()
+	for _, index := range c.sortedIndexes() {
+		holder := c.elevators[index]
+		if len(freeElevators) == 0 {
+			return
+		}
+		if !holder.canHandOver() {
+			continue
+		}
+		order := holder.currentOrder
+
+		candidates := []Elevator{}
+		for _, candidate := range freeElevators {
+			if candidate.index != index && candidate.isReadyFor(order) && candidate.canServe(order) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		// each candidate is estimated once, ties are broken by index
+		pickupTicks := holder.pickupTicks(order)
+		candidatesTicks := estimatePickupTicks(candidates, order)
+		best := candidates[0]
+		for _, candidate := range candidates[1:] {
+			if candidatesTicks[candidate.index] < candidatesTicks[best.index] {
+				best = candidate
+			}
+		}
+		bestTicks := candidatesTicks[best.index]
+		if pickupTicks-bestTicks <= c.reassignmentThreshold {
+			continue
+		}
+
+		newElevator, err := best.addOrder(order)
+		if err != nil {
+			continue
+		}
+		c.elevators[index], _ = holder.releaseOrder()
+		c.elevators[best.index] = newElevator
+		c.tripRequeued(order)
+		c.tripAssigned(order, best.index)
+		c.metrics.ReassignedOrders++
+		c.log().Info("reassign",
+			"tick", c.tick,
+			"order", order.String(),
+			"orderId", order.id,
+			"from", index,
+			"to", best.index,
+			"pickupTicks", pickupTicks,
+			"newPickupTicks", bestTicks,
+		)
+		freeElevators = c.freeElevators()
+	}
+}
@@ -0,0 +1,106 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestController_reassignOrders(t *testing.T) {
+	tests := []struct {
+		name                 string
+		threshold            int
+		wantTrip             Trip
+		wantReassignedOrders int
+	}{
+		{
+			name:     "disabled",
+			wantTrip: Trip{ID: 1, From: 9, To: 5, Elevator: 1, AssignTick: 1, PickupTick: 12, DeliveryTick: notYet},
+		},
+		{
+			name:                 "gain-above-threshold",
+			threshold:            2,
+			wantTrip:             Trip{ID: 1, From: 9, To: 5, Elevator: 2, AssignTick: 3, PickupTick: 6, DeliveryTick: 12, Reassignments: 1},
+			wantReassignedOrders: 1,
+		},
+		{
+			name:      "gain-equal-to-threshold",
+			threshold: 6,
+			wantTrip:  Trip{ID: 1, From: 9, To: 5, Elevator: 1, AssignTick: 1, PickupTick: 12, DeliveryTick: notYet},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.AddElevator(2)
+			// elevator 2 is about to deliver people at floor 8, close to the next call
+			controller.elevators[2] = Elevator{index: 2, position: 6, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8, id: 100}, motion: DefaultMotion, energyModel: DefaultEnergyModel}
+			controller.SetReassignmentThreshold(tt.threshold)
+			controller.EnableInvariantChecks()
+			controller.PushOrder(9, 5)
+			for i := 0; i < 12; i++ {
+				controller.step()
+			}
+
+			if got := controller.Trips(); !reflect.DeepEqual(got, []Trip{tt.wantTrip}) {
+				t.Errorf("Trips() = %+v, want %+v", got, []Trip{tt.wantTrip})
+			}
+			if got := controller.Metrics().ReassignedOrders; got != tt.wantReassignedOrders {
+				t.Errorf("ReassignedOrders = %d, want %d", got, tt.wantReassignedOrders)
+			}
+			if violations := controller.Violations(); len(violations) > 0 {
+				t.Errorf("Violations() = %v", violations)
+			}
+		})
+	}
+}
+
+func TestElevator_pickupTicks_heldOrder(t *testing.T) {
+	order := Order{from: 9, to: 5, id: 1}
+	elevator := Elevator{index: 1, position: 1, state: MovingEmptyTo{Floor(9)}, currentOrder: order, motion: DefaultMotion}
+
+	if got := elevator.pickupTicks(order); got != 9 {
+		t.Errorf("pickupTicks() = %d, want 9", got)
+	}
+}
+
+func TestElevator_canHandOver(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		want     bool
+	}{
+		{
+			name:     "moving-empty",
+			elevator: Elevator{position: 1, state: MovingEmptyTo{Floor(9)}, currentOrder: Order{from: 9, to: 5}},
+			want:     true,
+		},
+		{
+			name:     "unloading-with-the-next-order",
+			elevator: Elevator{position: 4, state: UnloadingAtFloor{Floor(4)}, currentOrder: Order{from: 9, to: 5}},
+			want:     true,
+		},
+		{
+			name:     "unloading-its-order",
+			elevator: Elevator{position: 4, state: UnloadingAtFloor{Floor(4)}, currentOrder: Order{from: 1, to: 4}},
+			want:     false,
+		},
+		{
+			name:     "unloading-jammed",
+			elevator: Elevator{position: 4, state: UnloadingAtFloor{Floor(4)}, currentOrder: Order{from: 9, to: 5}, fault: Fault{Kind: DoorJam, Ticks: 2}},
+			want:     false,
+		},
+		{
+			name:     "transporting",
+			elevator: Elevator{position: 4, state: TransportingPeopleTo{Floor(9)}, currentOrder: Order{from: 1, to: 9}},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.canHandOver(); got != tt.want {
+				t.Errorf("canHandOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package elevator
+
+import "fmt"
+
+type OperationMode int
+
+const (
+	NormalOperation OperationMode = iota
+	FireRecall
+)
+
+type scheduledRecall struct {
+	tick  int
+	floor Floor
+	clear bool
+}
+
+// TriggerFireRecall sends every elevator straight to the recall floor, people on board included.
+// Orders not picked up yet go back to the orders buffer, which is frozen until the recall is cleared
+func (c *Controller) TriggerFireRecall(floor int) error {
+	if !c.Building().contains(floorFromInt(floor)) {
+		return fmt.Errorf("recall floor %d is out of bound [%d-%d]", floor, c.Building().MinFloor, c.Building().MaxFloor)
+	}
+
+	c.mode = FireRecall
+	c.recallFloor = floorFromInt(floor)
+
+	for _, index := range c.sortedIndexes() {
+		newElevator, releasedOrder := c.elevators[index].recallTo(c.recallFloor)
+		c.elevators[index] = newElevator
+		if (Order{}) != releasedOrder {
+			c.requeueOrder(releasedOrder)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) ClearFireRecall() {
+	c.mode = NormalOperation
+}
+
+func (c *Controller) ScheduleFireRecall(tick int, floor int, durationInTicks int) {
+	c.scheduledRecalls = append(c.scheduledRecalls,
+		scheduledRecall{tick: tick, floor: floorFromInt(floor)},
+		scheduledRecall{tick: tick + durationInTicks, clear: true})
+}
+
+func (c *Controller) applyScheduledRecalls() error {
+	remainingRecalls := []scheduledRecall{}
+	for _, scheduled := range c.scheduledRecalls {
+		if scheduled.tick > c.tick {
+			remainingRecalls = append(remainingRecalls, scheduled)
+		} else if scheduled.clear {
+			c.ClearFireRecall()
+		} else {
+			err := c.TriggerFireRecall(scheduled.floor.toInt())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	c.scheduledRecalls = remainingRecalls
+	return nil
+}
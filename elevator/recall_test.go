@@ -0,0 +1,167 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElevator_recallTo(t *testing.T) {
+	tests := []struct {
+		name          string
+		elevator      Elevator
+		want          Elevator
+		wantGivenBack Order
+	}{
+		{
+			name: "moving-empty-cancels-hall-call",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     Floor(3),
+				state:        MovingEmptyTo{Floor(5)},
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{},
+				position:     Floor(3),
+				state:        RecallingTo{Floor(0)},
+			},
+			wantGivenBack: Order{from: Floor(5), to: Floor(2)},
+		},
+		{
+			name: "transporting-goes-straight-to-recall-floor",
+			elevator: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(6)},
+				position:     Floor(3),
+				state:        TransportingPeopleTo{Floor(6)},
+			},
+			want: Elevator{
+				index:        1,
+				currentOrder: Order{from: Floor(1), to: Floor(6)},
+				position:     Floor(3),
+				state:        RecallingTo{Floor(0)},
+			},
+			wantGivenBack: Order{},
+		},
+		{
+			name: "parked-at-recall-floor",
+			elevator: Elevator{
+				index:    1,
+				position: Floor(0),
+				state:    StopAtFloor{Floor(0)},
+			},
+			want: Elevator{
+				index:    1,
+				position: Floor(0),
+				state:    StopAtFloor{Floor(0)},
+			},
+			wantGivenBack: Order{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, givenBack := tt.elevator.recallTo(Floor(0))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recallTo() = \n%+v\n, want \n%+v\n", got, tt.want)
+			}
+			if givenBack != tt.wantGivenBack {
+				t.Errorf("recallTo() given back order = %v, want %v", givenBack, tt.wantGivenBack)
+			}
+		})
+	}
+}
+
+func TestElevator_nextState_recalling(t *testing.T) {
+	elevator := Elevator{
+		index:        1,
+		currentOrder: Order{from: Floor(1), to: Floor(6)},
+		position:     Floor(2),
+		state:        RecallingTo{Floor(1)},
+	}
+
+	arrived := elevator.nextState()
+	if arrived.position != Floor(1) || arrived.state != (RecallingTo{Floor(1)}) {
+		t.Errorf("nextState() = %+v, want recalling at floor 1", arrived)
+	}
+
+	parked := arrived.nextState()
+	want := Elevator{index: 1, currentOrder: Order{}, position: Floor(1), state: StopAtFloor{Floor(1)}}
+	if !reflect.DeepEqual(parked, want) {
+		t.Errorf("nextState() = \n%+v\n, want \n%+v\n", parked, want)
+	}
+}
+
+func TestController_TriggerFireRecall(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {
+				index:        1,
+				currentOrder: Order{from: Floor(5), to: Floor(2)},
+				position:     3,
+				state:        MovingEmptyTo{Floor(5)},
+			},
+			2: {
+				index:        2,
+				currentOrder: Order{from: Floor(1), to: Floor(4)},
+				position:     2,
+				state:        TransportingPeopleTo{Floor(4)},
+			},
+		},
+		ordersBuffer: Orders{Order{from: Floor(0), to: Floor(6)}},
+	}
+
+	if err := controller.TriggerFireRecall(1); err != nil {
+		t.Fatalf("TriggerFireRecall() unexpected error = %v", err)
+	}
+
+	wantBuffer := Orders{Order{from: Floor(5), to: Floor(2)}, Order{from: Floor(0), to: Floor(6)}}
+	if !reflect.DeepEqual(controller.ordersBuffer, wantBuffer) {
+		t.Errorf("ordersBuffer = \n%+v\n, wanted \n%+v\n", controller.ordersBuffer, wantBuffer)
+	}
+
+	for tick := 0; tick < 4; tick++ {
+		if err := controller.step(); err != nil {
+			t.Fatalf("step() unexpected error = %v", err)
+		}
+	}
+	for _, elevator := range controller.elevators {
+		if !elevator.isParked() || elevator.position != Floor(1) {
+			t.Errorf("elevator n°%d should be parked at recall floor, got %+v", elevator.index, elevator)
+		}
+	}
+	if !reflect.DeepEqual(controller.ordersBuffer, wantBuffer) {
+		t.Errorf("ordersBuffer should stay frozen during the recall, got %+v", controller.ordersBuffer)
+	}
+	if !controller.isOver() {
+		t.Errorf("simulation should be over once every elevator is recalled without clearance scheduled")
+	}
+
+	controller.ClearFireRecall()
+	controller.step()
+	if len(controller.ordersBuffer) != 1 {
+		t.Errorf("dispatch should resume once the recall is cleared, ordersBuffer = %+v", controller.ordersBuffer)
+	}
+
+	if err := controller.TriggerFireRecall(12); err == nil || err.Error() != "recall floor 12 is out of bound [0-9]" {
+		t.Errorf("TriggerFireRecall() error = %v", err)
+	}
+}
+
+func TestController_ScheduleFireRecall(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(3, 5)
+	controller.ScheduleFireRecall(1, 0, 3)
+
+	modes := []OperationMode{}
+	for tick := 0; tick < 5; tick++ {
+		controller.step()
+		modes = append(modes, controller.mode)
+	}
+
+	want := []OperationMode{FireRecall, FireRecall, FireRecall, NormalOperation, NormalOperation}
+	if !reflect.DeepEqual(modes, want) {
+		t.Errorf("modes = %v, want %v", modes, want)
+	}
+}
@@ -0,0 +1,187 @@
+package elevator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Snapshot is a read-only picture of the controller at a given tick, for the renderers
+type Snapshot struct {
+	Tick           int                `json:"tick"`
+	MinFloor       int                `json:"minFloor"`
+	MaxFloor       int                `json:"maxFloor"`
+	OrdersBuffer   []OrderSnapshot    `json:"ordersBuffer"`
+	RejectedOrders []OrderSnapshot    `json:"rejectedOrders"`
+	FireRecall     bool               `json:"fireRecall"`
+	RecallFloor    int                `json:"recallFloor"`
+	Metrics        Metrics            `json:"metrics"`
+	Elevators      []ElevatorSnapshot `json:"elevators"`
+}
+
+type OrderSnapshot struct {
+	ID       int      `json:"id"`
+	From     int      `json:"from"`
+	To       int      `json:"to"`
+	Priority Priority `json:"priority"`
+	Weight   int      `json:"weight,omitempty"`
+}
+
+func (o OrderSnapshot) String() string {
+	return Order{from: floorFromInt(o.From), to: floorFromInt(o.To), priority: o.Priority, id: o.ID}.String()
+}
+
+type ElevatorSnapshot struct {
+	Index    int    `json:"index"`
+	Position int    `json:"position"`
+	State    string `json:"state"`
+	// Target is the floor the state leads to: destination, pickup, recall or parking floor
+	Target int            `json:"target"`
+	Order  *OrderSnapshot `json:"order,omitempty"`
+	// Boarded tells if people of the order are on board, WaitingPickup if they still wait for the elevator
+	Boarded       bool   `json:"boarded"`
+	WaitingPickup bool   `json:"waitingPickup"`
+	OutOfService  bool   `json:"outOfService"`
+	Fault         string `json:"fault,omitempty"`
+	// Load and RatedLoad are in kilograms, Overloaded is set while the car loads more people than it can carry
+	Load       int  `json:"load,omitempty"`
+	RatedLoad  int  `json:"ratedLoad,omitempty"`
+	Overloaded bool `json:"overloaded,omitempty"`
+	// Riders are the orders picked up on the way, on board with the order of the car
+	Riders []OrderSnapshot `json:"riders,omitempty"`
+}
+
+type Renderer interface {
+	Render(snapshot Snapshot) string
+}
+
+func RendererFromString(renderer string) (Renderer, error) {
+	switch renderer {
+	case "", "line":
+		return LineRenderer{}, nil
+	case "vertical":
+		return VerticalRenderer{}, nil
+	case "json":
+		return JSONRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown view '%s', expected one of line, vertical, json", renderer)
+	}
+}
+
+func (c *Controller) SetRenderer(renderer Renderer) {
+	c.renderer = renderer
+}
+
+func (o Order) snapshot() OrderSnapshot {
+	return OrderSnapshot{ID: o.id, From: o.from.toInt(), To: o.to.toInt(), Priority: o.priority, Weight: o.weight}
+}
+
+func ordersSnapshot(orders Orders) []OrderSnapshot {
+	snapshots := []OrderSnapshot{}
+	for _, order := range orders {
+		snapshots = append(snapshots, order.snapshot())
+	}
+	return snapshots
+}
+
+func (e Elevator) snapshot() ElevatorSnapshot {
+	snapshot := ElevatorSnapshot{
+		Index:         e.index,
+		Position:      e.position.toInt(),
+		State:         reflect.TypeOf(e.state).Name(),
+		Target:        e.state.floor().toInt(),
+		Boarded:       e.isCarryingPeople(),
+		WaitingPickup: (Order{}) != e.pendingPickup(),
+		OutOfService:  e.outOfService,
+		Load:          e.load(),
+		RatedLoad:     e.ratedLoad,
+		Overloaded:    e.overloaded,
+	}
+	if (Order{}) != e.currentOrder {
+		order := e.currentOrder.snapshot()
+		snapshot.Order = &order
+	}
+	if len(e.riders) > 0 {
+		snapshot.Riders = ordersSnapshot(e.riders)
+	}
+	if e.fault.isActive() {
+		snapshot.Fault = e.fault.String()
+	}
+	return snapshot
+}
+
+func (c *Controller) Snapshot() Snapshot {
+	snapshot := Snapshot{
+		Tick:           c.tick,
+		MinFloor:       c.Building().MinFloor,
+		MaxFloor:       c.Building().MaxFloor,
+		OrdersBuffer:   ordersSnapshot(c.ordersBuffer),
+		RejectedOrders: ordersSnapshot(c.rejectedOrders),
+		FireRecall:     c.mode == FireRecall,
+		RecallFloor:    c.recallFloor.toInt(),
+		Metrics:        c.metrics,
+		Elevators:      []ElevatorSnapshot{},
+	}
+	for _, index := range c.sortedIndexes() {
+		snapshot.Elevators = append(snapshot.Elevators, c.elevators[index].snapshot())
+	}
+	return snapshot
+}
+
+// people gives the orders waiting at each floor and the orders going to each floor, the recalled ones aside
+func (s Snapshot) people() (map[int][]OrderSnapshot, map[int][]OrderSnapshot) {
+	waiting := map[int][]OrderSnapshot{}
+	destinations := map[int][]OrderSnapshot{}
+	for _, order := range s.OrdersBuffer {
+		waiting[order.From] = append(waiting[order.From], order)
+		destinations[order.To] = append(destinations[order.To], order)
+	}
+	for _, elevator := range s.Elevators {
+		if elevator.WaitingPickup {
+			pickup := *elevator.Order
+			waiting[pickup.From] = append(waiting[pickup.From], pickup)
+			destinations[pickup.To] = append(destinations[pickup.To], pickup)
+		} else if elevator.Boarded && elevator.State != "RecallingTo" {
+			destinations[elevator.Order.To] = append(destinations[elevator.Order.To], *elevator.Order)
+		}
+		if elevator.State != "RecallingTo" {
+			for _, rider := range elevator.Riders {
+				destinations[rider.To] = append(destinations[rider.To], rider)
+			}
+		}
+	}
+	return waiting, destinations
+}
+
+func (c *Controller) display() string {
+	if c.renderer == nil {
+		return LineRenderer{}.Render(c.Snapshot())
+	}
+	return c.renderer.Render(c.Snapshot())
+}
+
+// renderHeader is the common header of the text renderers
+func renderHeader(snapshot Snapshot) string {
+	display := "\n\n\tElevators state: \n"
+	display += fmt.Sprintf("\tOrdersBuffer: %v\n", snapshot.OrdersBuffer)
+	if len(snapshot.RejectedOrders) > 0 {
+		display += fmt.Sprintf("\tRejectedOrders: %v\n", snapshot.RejectedOrders)
+	}
+	if snapshot.FireRecall {
+		display += fmt.Sprintf("\tFIRE RECALL to floor %d, orders are frozen\n", snapshot.RecallFloor)
+	}
+	display += fmt.Sprintf("\tMetrics: %s\n", snapshot.Metrics)
+	display += "\n"
+	return display
+}
+
+type JSONRenderer struct {
+}
+
+func (j JSONRenderer) Render(snapshot Snapshot) string {
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Sprintf("{\"error\": %q}", err.Error())
+	}
+	return string(content)
+}
@@ -0,0 +1,79 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestController_Snapshot(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			2: {index: 2, currentOrder: Order{from: Floor(5), to: Floor(1)}, position: 3, state: MovingEmptyTo{Floor(5)}},
+			1: {index: 1, currentOrder: Order{from: Floor(1), to: Floor(4)}, position: 2, state: TransportingPeopleTo{Floor(4)}, fault: Fault{Kind: StuckBetweenFloors, Ticks: 2}},
+		},
+		ordersBuffer: Orders{Order{from: Floor(8), to: Floor(5), priority: VIPPriority}},
+		tick:         7,
+	}
+
+	want := Snapshot{
+		Tick:           7,
+		MinFloor:       0,
+		MaxFloor:       9,
+		OrdersBuffer:   []OrderSnapshot{{From: 8, To: 5, Priority: VIPPriority}},
+		RejectedOrders: []OrderSnapshot{},
+		Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 2, State: "TransportingPeopleTo", Target: 4, Order: &OrderSnapshot{From: 1, To: 4}, Boarded: true, Fault: Fault{Kind: StuckBetweenFloors, Ticks: 2}.String()},
+			{Index: 2, Position: 3, State: "MovingEmptyTo", Target: 5, Order: &OrderSnapshot{From: 5, To: 1}, WaitingPickup: true},
+		},
+	}
+
+	if got := controller.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	snapshot := Snapshot{
+		Tick:           3,
+		MaxFloor:       9,
+		OrdersBuffer:   []OrderSnapshot{},
+		RejectedOrders: []OrderSnapshot{},
+		Elevators: []ElevatorSnapshot{
+			{Index: 1, Position: 2, State: "StopAtFloor", Target: 2},
+		},
+	}
+
+	want := `{"tick":3,"minFloor":0,"maxFloor":9,"ordersBuffer":[],"rejectedOrders":[],"fireRecall":false,"recallFloor":0,` +
+		`"metrics":{"faults":0,"reassignedOrders":0,"strandedOrders":0,"energyKWh":0,"overdueOrders":0,"cancelledOrders":0,"overloads":0,"pickupsOnTheWay":0},` +
+		`"elevators":[{"index":1,"position":2,"state":"StopAtFloor","target":2,"boarded":false,"waitingPickup":false,"outOfService":false}]}`
+
+	if got := (JSONRenderer{}).Render(snapshot); got != want {
+		t.Errorf("Render() = \n%v\n, want \n%v\n", got, want)
+	}
+}
+
+func TestRendererFromString(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Renderer
+		wantErr bool
+	}{
+		{name: "", want: LineRenderer{}},
+		{name: "line", want: LineRenderer{}},
+		{name: "vertical", want: VerticalRenderer{}},
+		{name: "json", want: JSONRenderer{}},
+		{name: "html", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RendererFromString(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RendererFromString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RendererFromString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
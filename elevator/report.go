@@ -0,0 +1,376 @@
+package elevator
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// reportColours are the colours of the states in the HTML report, the same as in the GIF export
+var reportColours = map[string]string{
+	"StopAtFloor":          "#e6e6e6",
+	"LoadingAtFloor":       "#3cb44b",
+	"UnloadingAtFloor":     "#46c8dc",
+	"MovingEmptyTo":        "#969696",
+	"RepositioningTo":      "#969696",
+	"TransportingPeopleTo": "#3c6ee6",
+	"RecallingTo":          "#dc3232",
+	"Fault":                "#dc3232",
+}
+
+type reportKPIs struct {
+	Ticks       int
+	Orders      int
+	Delivered   int
+	Rejected    int
+	Cancelled   int
+	Undelivered int
+	AverageWait string
+	MaxWait     int
+	AverageRide string
+	MaxRide     int
+	Metrics     Metrics
+}
+
+type reportSegment struct {
+	State  string
+	Colour string
+	Start  int
+	Ticks  int
+	// Percent is the width of the segment on the timeline
+	Percent float64
+}
+
+type reportTimeline struct {
+	Index    int
+	Segments []reportSegment
+}
+
+type reportTrip struct {
+	Trip
+	Label    string
+	Status   string
+	Wait     string
+	Ride     string
+	Elevator string
+	Ticks    [4]string
+}
+
+type reportCar struct {
+	Index     int    `json:"index"`
+	Position  int    `json:"position"`
+	Pictogram string `json:"pictogram"`
+	Colour    string `json:"colour"`
+}
+
+type reportFrame struct {
+	Tick         int            `json:"tick"`
+	Queue        int            `json:"queue"`
+	Cars         []reportCar    `json:"cars"`
+	Waiting      map[int]string `json:"waiting"`
+	Destinations map[int]string `json:"destinations"`
+}
+
+type report struct {
+	KPIs      reportKPIs
+	Timelines []reportTimeline
+	Trips     []reportTrip
+	Frames    []reportFrame
+	MinFloor  int
+	MaxFloor  int
+}
+
+// WriteHTMLReport writes a single HTML page, usable offline, with the KPIs of the run, the timeline of each elevator,
+// the trip of each order and a replay of the recorded snapshots
+func WriteHTMLReport(out io.Writer, snapshots []Snapshot, trips []Trip) error {
+	if len(snapshots) == 0 {
+		return fmt.Errorf("there is no recorded tick to report")
+	}
+
+	page, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return err
+	}
+
+	last := snapshots[len(snapshots)-1]
+	return page.Execute(out, report{
+		KPIs:      computeKPIs(last, trips),
+		Timelines: computeTimelines(snapshots),
+		Trips:     reportTrips(trips),
+		Frames:    replayFrames(snapshots),
+		MinFloor:  last.MinFloor,
+		MaxFloor:  last.MaxFloor,
+	})
+}
+
+func stateCSSColour(e ElevatorSnapshot) string {
+	if e.Fault != "" {
+		return reportColours["Fault"]
+	}
+	return reportColours[e.State]
+}
+
+func average(total int, count int) string {
+	if count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(total)/float64(count))
+}
+
+func computeKPIs(last Snapshot, trips []Trip) reportKPIs {
+	kpis := reportKPIs{Ticks: last.Tick, Orders: len(trips), Metrics: last.Metrics}
+	totalWait, totalRide := 0, 0
+	for _, trip := range trips {
+		if trip.Rejected {
+			kpis.Rejected++
+		} else if trip.Cancelled {
+			// people who cancelled their order did not wait for a car, they are not counted as undelivered
+			kpis.Cancelled++
+		} else if !trip.Delivered() {
+			kpis.Undelivered++
+		} else {
+			kpis.Delivered++
+			totalWait += trip.WaitTicks()
+			totalRide += trip.RideTicks()
+			if trip.WaitTicks() > kpis.MaxWait {
+				kpis.MaxWait = trip.WaitTicks()
+			}
+			if trip.RideTicks() > kpis.MaxRide {
+				kpis.MaxRide = trip.RideTicks()
+			}
+		}
+	}
+	kpis.AverageWait = average(totalWait, kpis.Delivered)
+	kpis.AverageRide = average(totalRide, kpis.Delivered)
+	return kpis
+}
+
+// computeTimelines merges the consecutive ticks an elevator spends in the same state
+func computeTimelines(snapshots []Snapshot) []reportTimeline {
+	timelines := []reportTimeline{}
+	positions := map[int]int{}
+	for _, snapshot := range snapshots {
+		for _, elevator := range snapshot.Elevators {
+			state := elevator.State
+			if elevator.Fault != "" {
+				state = "Fault"
+			}
+
+			position, ok := positions[elevator.Index]
+			if !ok {
+				position = len(timelines)
+				positions[elevator.Index] = position
+				timelines = append(timelines, reportTimeline{Index: elevator.Index})
+			}
+
+			segments := timelines[position].Segments
+			if len(segments) > 0 && segments[len(segments)-1].State == state {
+				segments[len(segments)-1].Ticks++
+			} else {
+				segments = append(segments, reportSegment{State: state, Colour: stateCSSColour(elevator), Start: snapshot.Tick, Ticks: 1})
+			}
+			timelines[position].Segments = segments
+		}
+	}
+
+	for _, timeline := range timelines {
+		for i := range timeline.Segments {
+			timeline.Segments[i].Percent = 100 * float64(timeline.Segments[i].Ticks) / float64(len(snapshots))
+		}
+	}
+	return timelines
+}
+
+func reportTick(tick int) string {
+	if tick == notYet {
+		return "-"
+	}
+	return fmt.Sprintf("%d", tick)
+}
+
+func reportTrips(trips []Trip) []reportTrip {
+	rows := []reportTrip{}
+	for _, trip := range trips {
+		row := reportTrip{
+			Trip:     trip,
+			Label:    Order{from: floorFromInt(trip.From), to: floorFromInt(trip.To), priority: trip.Priority}.String(),
+			Wait:     "-",
+			Ride:     "-",
+			Elevator: reportTick(trip.Elevator),
+			Ticks:    [4]string{reportTick(trip.PushTick), reportTick(trip.AssignTick), reportTick(trip.PickupTick), reportTick(trip.DeliveryTick)},
+		}
+		switch {
+		case trip.Rejected:
+			row.Status = "rejected"
+		case trip.Cancelled:
+			row.Status = "cancelled"
+		case trip.Delivered():
+			row.Status = "delivered"
+			row.Wait = fmt.Sprintf("%d", trip.WaitTicks())
+			row.Ride = fmt.Sprintf("%d", trip.RideTicks())
+		case trip.PickupTick != notYet:
+			row.Status = "on board"
+		default:
+			row.Status = "waiting"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func replayFrames(snapshots []Snapshot) []reportFrame {
+	frames := []reportFrame{}
+	for _, snapshot := range snapshots {
+		frame := reportFrame{Tick: snapshot.Tick, Queue: len(snapshot.OrdersBuffer), Cars: []reportCar{}, Waiting: map[int]string{}, Destinations: map[int]string{}}
+		for _, elevator := range snapshot.Elevators {
+			frame.Cars = append(frame.Cars, reportCar{
+				Index:     elevator.Index,
+				Position:  elevator.Position,
+				Pictogram: VerticalRenderer{}.pictogram(elevator),
+				Colour:    stateCSSColour(elevator),
+			})
+		}
+		waiting, destinations := snapshot.people()
+		for floor, orders := range waiting {
+			frame.Waiting[floor] = fmt.Sprintf("%d☹%d %s", floor, floor, joinOrders(orders))
+		}
+		for floor, orders := range destinations {
+			frame.Destinations[floor] = fmt.Sprintf("❲%d❳ %s", floor, joinOrders(orders))
+		}
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+const reportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Elevators simulation report</title>
+<style>
+  body { font-family: sans-serif; margin: 2em; color: #222; }
+  h2 { margin-top: 1.5em; }
+  .kpis { display: flex; flex-wrap: wrap; gap: 1em; }
+  .kpi { border: 1px solid #ccc; border-radius: 6px; padding: 0.6em 1em; min-width: 8em; }
+  .kpi b { display: block; font-size: 1.4em; }
+  .timeline { display: flex; height: 1.4em; border: 1px solid #999; margin: 0.2em 0 0.6em 0; }
+  .timeline div { height: 100%; }
+  table { border-collapse: collapse; }
+  th, td { border: 1px solid #ccc; padding: 0.2em 0.6em; text-align: center; }
+  #building td { width: 3em; height: 1.6em; font-family: monospace; }
+  #building td.people { text-align: left; width: auto; border: none; }
+  .legend span { display: inline-block; padding: 0 0.5em; margin-right: 0.3em; }
+</style>
+</head>
+<body>
+<h1>Elevators simulation report</h1>
+
+<h2>KPIs</h2>
+<div class="kpis">
+  <div class="kpi">ticks<b>{{.KPIs.Ticks}}</b></div>
+  <div class="kpi">orders<b>{{.KPIs.Orders}}</b></div>
+  <div class="kpi">delivered<b>{{.KPIs.Delivered}}</b></div>
+  <div class="kpi">rejected<b>{{.KPIs.Rejected}}</b></div>
+  <div class="kpi">cancelled<b>{{.KPIs.Cancelled}}</b></div>
+  <div class="kpi">not delivered<b>{{.KPIs.Undelivered}}</b></div>
+  <div class="kpi">average wait<b>{{.KPIs.AverageWait}}</b></div>
+  <div class="kpi">max wait<b>{{.KPIs.MaxWait}}</b></div>
+  <div class="kpi">average ride<b>{{.KPIs.AverageRide}}</b></div>
+  <div class="kpi">max ride<b>{{.KPIs.MaxRide}}</b></div>
+  <div class="kpi">energy (kWh)<b>{{printf "%.2f" .KPIs.Metrics.EnergyKWh}}</b></div>
+  <div class="kpi">faults<b>{{.KPIs.Metrics.Faults}}</b></div>
+  <div class="kpi">reassigned orders<b>{{.KPIs.Metrics.ReassignedOrders}}</b></div>
+  <div class="kpi">stranded orders<b>{{.KPIs.Metrics.StrandedOrders}}</b></div>
+</div>
+
+<h2>Elevator timelines</h2>
+<div class="legend">
+  <span style="background:#e6e6e6">StopAtFloor</span><span style="background:#3cb44b">LoadingAtFloor</span>
+  <span style="background:#46c8dc">UnloadingAtFloor</span><span style="background:#969696">MovingEmptyTo / RepositioningTo</span>
+  <span style="background:#3c6ee6;color:white">TransportingPeopleTo</span><span style="background:#dc3232;color:white">RecallingTo / Fault</span>
+</div>
+{{range .Timelines}}
+<div>Elevator n°{{.Index}}</div>
+<div class="timeline">{{range .Segments}}<div style="width:{{.Percent}}%;background:{{.Colour}}" title="{{.State}} from tick {{.Start}} during {{.Ticks}} tick(s)"></div>{{end}}</div>
+{{end}}
+
+<h2>Trips</h2>
+<table>
+  <tr><th>id</th><th>order</th><th>priority</th><th>elevator</th><th>pushed</th><th>assigned</th><th>picked up</th><th>delivered</th><th>wait</th><th>ride</th><th>reassignments</th><th>status</th></tr>
+  {{range .Trips}}<tr><td>{{.ID}}</td><td>{{.Label}}</td><td>{{.Priority}}</td><td>{{.Elevator}}</td>{{range .Ticks}}<td>{{.}}</td>{{end}}<td>{{.Wait}}</td><td>{{.Ride}}</td><td>{{.Reassignments}}</td><td>{{.Status}}</td></tr>
+  {{end}}
+</table>
+
+<h2>Replay</h2>
+<p>
+  <button id="play">play</button>
+  <input id="slider" type="range" min="0" max="0" value="0" style="width:30em">
+  <span id="status"></span>
+</p>
+<table id="building"></table>
+
+<script>
+const frames = {{.Frames}};
+const minFloor = {{.MinFloor}};
+const maxFloor = {{.MaxFloor}};
+const slider = document.getElementById("slider");
+const building = document.getElementById("building");
+const status = document.getElementById("status");
+const play = document.getElementById("play");
+let timer = null;
+
+slider.max = frames.length - 1;
+
+function escape(text) {
+  return text.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;");
+}
+
+function draw(index) {
+  const frame = frames[index];
+  status.textContent = "tick " + frame.tick + " | " + frame.queue + " order(s) in queue";
+  let rows = "<tr><th></th>" + frame.cars.map(car => "<th>" + car.index + "</th>").join("") + "<th></th></tr>";
+  for (let floor = maxFloor; floor >= minFloor; floor--) {
+    rows += "<tr><th>" + floor + "</th>";
+    for (const car of frame.cars) {
+      if (car.position === floor) {
+        rows += "<td style=\"background:" + car.colour + "\">" + escape(car.pictogram) + "</td>";
+      } else {
+        rows += "<td></td>";
+      }
+    }
+    rows += "<td class=\"people\">" + escape((frame.waiting[floor] || "") + " " + (frame.destinations[floor] || "")) + "</td></tr>";
+  }
+  building.innerHTML = rows;
+}
+
+function stop() {
+  clearInterval(timer);
+  timer = null;
+  play.textContent = "play";
+}
+
+play.addEventListener("click", () => {
+  if (timer !== null) {
+    stop();
+    return;
+  }
+  if (Number(slider.value) >= frames.length - 1) {
+    slider.value = 0;
+  }
+  play.textContent = "pause";
+  timer = setInterval(() => {
+    if (Number(slider.value) >= frames.length - 1) {
+      stop();
+      return;
+    }
+    slider.value = Number(slider.value) + 1;
+    draw(Number(slider.value));
+  }, 500);
+});
+
+slider.addEventListener("input", () => draw(Number(slider.value)));
+draw(0);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,99 @@
+package elevator
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeKPIs(t *testing.T) {
+	trips := []Trip{
+		{ID: 1, PushTick: 0, PickupTick: 2, DeliveryTick: 5},
+		{ID: 2, PushTick: 1, PickupTick: 7, DeliveryTick: 8},
+		{ID: 3, PushTick: 1, PickupTick: 3, DeliveryTick: notYet},
+		{ID: 4, PushTick: 2, PickupTick: notYet, DeliveryTick: notYet, Rejected: true},
+		{ID: 5, PushTick: 3, PickupTick: notYet, DeliveryTick: notYet, Cancelled: true},
+	}
+
+	want := reportKPIs{Ticks: 9, Orders: 5, Delivered: 2, Rejected: 1, Cancelled: 1, Undelivered: 1, AverageWait: "4.0", MaxWait: 6, AverageRide: "2.0", MaxRide: 3}
+	if got := computeKPIs(Snapshot{Tick: 9}, trips); !reflect.DeepEqual(got, want) {
+		t.Errorf("computeKPIs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestComputeTimelines(t *testing.T) {
+	snapshots := []Snapshot{
+		{Tick: 0, Elevators: []ElevatorSnapshot{{Index: 1, State: "StopAtFloor"}}},
+		{Tick: 1, Elevators: []ElevatorSnapshot{{Index: 1, State: "MovingEmptyTo"}}},
+		{Tick: 2, Elevators: []ElevatorSnapshot{{Index: 1, State: "MovingEmptyTo", Fault: "STUCK"}}},
+		{Tick: 3, Elevators: []ElevatorSnapshot{{Index: 1, State: "MovingEmptyTo", Fault: "STUCK"}}},
+	}
+
+	want := []reportTimeline{{Index: 1, Segments: []reportSegment{
+		{State: "StopAtFloor", Colour: "#e6e6e6", Start: 0, Ticks: 1, Percent: 25},
+		{State: "MovingEmptyTo", Colour: "#969696", Start: 1, Ticks: 1, Percent: 25},
+		{State: "Fault", Colour: "#dc3232", Start: 2, Ticks: 2, Percent: 50},
+	}}}
+	if got := computeTimelines(snapshots); !reflect.DeepEqual(got, want) {
+		t.Errorf("computeTimelines() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushPriorityOrder(0, 2, MedicalEmergencyPriority)
+	snapshots := controller.Record(100)
+
+	out := &bytes.Buffer{}
+	if err := WriteHTMLReport(out, snapshots, controller.Trips()); err != nil {
+		t.Fatalf("WriteHTMLReport() error = %v", err)
+	}
+
+	page := out.String()
+	for _, want := range []string{
+		`<div class="kpi">delivered<b>1</b></div>`,
+		`<tr><td>1</td><td>&lt;0-&gt;2&gt;</td><td>medical</td><td>1</td><td>0</td><td>1</td><td>2</td><td>6</td><td>2</td><td>4</td><td>0</td><td>delivered</td></tr>`,
+		`title="LoadingAtFloor from tick 2 during 1 tick(s)"`,
+		`const frames = [{"tick":0,"queue":1,`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("the report should contain %s", want)
+		}
+	}
+	if strings.Contains(page, "ZgotmplZ") {
+		t.Errorf("the template rejected some values of the report")
+	}
+
+	if err := WriteHTMLReport(out, nil, nil); err == nil {
+		t.Errorf("WriteHTMLReport() should fail without any tick")
+	}
+}
+
+func TestWriteHTMLReport_sameRunSameReport(t *testing.T) {
+	report := func() string {
+		controller := NewController(0)
+		controller.AddElevator(1)
+		controller.AddElevator(2)
+		controller.AddElevator(3)
+		for _, order := range [][2]int{{0, 5}, {0, 7}, {3, 1}, {9, 0}, {2, 6}, {6, 2}} {
+			controller.PushOrder(order[0], order[1])
+		}
+		controller.ScheduleFault(3, 2, Fault{Kind: StuckBetweenFloors, Ticks: 3})
+		snapshots := controller.Record(100)
+
+		out := &bytes.Buffer{}
+		if err := WriteHTMLReport(out, snapshots, controller.Trips()); err != nil {
+			t.Fatalf("WriteHTMLReport() error = %v", err)
+		}
+		return out.String()
+	}
+
+	first := report()
+	for run := 0; run < 5; run++ {
+		if report() != first {
+			t.Fatalf("the same run should always write the same report")
+		}
+	}
+}
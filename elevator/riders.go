@@ -0,0 +1,156 @@
+package elevator
+
+// Riders are the orders picked up on the way: a car passing the floor of a pending order, in the same
+// direction, stops to let people board when they get off before the end of its current leg. The car
+// keeps going to its target and stops one tick at each floor where riders get off.
+// A car only notices the people waiting at the floor it reaches at the end of a tick: a car crossing
+// several floors in one tick goes past the floors in between, as it could not brake there in time.
+
+// legTarget is the floor the car moves to before opening its doors for its current order
+func (e Elevator) legTarget() (Floor, bool) {
+	switch e.state.(type) {
+	case MovingEmptyTo, TransportingPeopleTo:
+		return e.state.floor(), true
+	default:
+		return 0, false
+	}
+}
+
+func isBetween(floor Floor, from Floor, to Floor) bool {
+	if from < to {
+		return floor > from && floor <= to
+	}
+	return floor < from && floor >= to
+}
+
+// canPickUpOnTheWay tells if the people of the order, waiting at the floor the car just reached, can ride along.
+// Their destination must be within the current leg, people going further wait for a car of their own
+func (e Elevator) canPickUpOnTheWay(order Order) bool {
+	target, moving := e.legTarget()
+	if !moving || e.position == target || order.from != e.position || e.outOfService || e.fault.isActive() {
+		return false
+	}
+	return isBetween(order.to, e.position, target) && e.canServe(order) && e.canStopFor(order)
+}
+
+func (e Elevator) withRider(order Order) Elevator {
+	newElevator := e
+	newElevator.riders = append(append(Orders{}, e.riders...), order)
+	newElevator.stopping = true
+	return newElevator
+}
+
+// nextStop is the closest floor where riders get off before the target
+func (e Elevator) nextStop(target Floor) Floor {
+	stop := target
+	for _, rider := range e.riders {
+		if isBetween(rider.to, e.position, stop) {
+			stop = rider.to
+		}
+	}
+	return stop
+}
+
+// dropRiders lets the riders get off at the floor of the car
+func (e Elevator) dropRiders() Elevator {
+	var riders Orders
+	for _, rider := range e.riders {
+		if rider.to != e.position {
+			riders = append(riders, rider)
+		}
+	}
+	newElevator := e
+	newElevator.riders = riders
+	return newElevator
+}
+
+// moveWithRiders moves the car towards its target and stops at the floors where riders get off
+func (e Elevator) moveWithRiders(target Floor, state State) Elevator {
+	if e.stopping {
+		// the doors stay open one tick for riders to board or get off
+		newElevator := e.dropRiders()
+		newElevator.stopping = false
+		newElevator.velocity = 0
+		newElevator.progress = 0
+		newElevator.state = state
+		return newElevator
+	}
+	newElevator := e.moveTowards(e.nextStop(target), state)
+	if newElevator.position != target {
+		for _, rider := range newElevator.riders {
+			if rider.to == newElevator.position {
+				newElevator.stopping = true
+			}
+		}
+	}
+	return newElevator
+}
+
+// promoteRider makes the rider going the farthest the current order of the car, when its order is released
+func (e Elevator) promoteRider() Elevator {
+	farthest := 0
+	for index, rider := range e.riders {
+		if e.computeDistance(e.position, rider.to) > e.computeDistance(e.position, e.riders[farthest].to) {
+			farthest = index
+		}
+	}
+	newElevator := e.withOrderAndState(e.riders[farthest], TransportingPeopleTo{e.riders[farthest].to})
+	newElevator.riders = removeOrder(e.riders, farthest)
+	if len(newElevator.riders) == 0 {
+		newElevator.riders = nil
+	}
+	return newElevator
+}
+
+// pickUpOnTheWay folds pending orders into the route of the cars passing their floor
+func (c *Controller) pickUpOnTheWay(before map[int]Elevator) {
+	if c.mode == FireRecall {
+		return
+	}
+	for _, index := range c.sortedIndexes() {
+		if previousElevator, ok := before[index]; !ok || previousElevator.position == c.elevators[index].position {
+			// a car starting its leg has not passed any floor yet
+			continue
+		}
+		for orderIndex := 0; orderIndex < len(c.ordersBuffer); orderIndex++ {
+			order := c.ordersBuffer[orderIndex]
+			if !c.elevators[index].canPickUpOnTheWay(order) {
+				continue
+			}
+			c.elevators[index] = c.elevators[index].withRider(order)
+			c.ordersBuffer = removeOrder(c.ordersBuffer, orderIndex)
+			orderIndex--
+			c.metrics.PickupsOnTheWay++
+			c.tripAssigned(order, index)
+			c.log().Info("pickup on the way", "tick", c.tick, "elevator", index, "order", order.String(), "orderId", order.id)
+		}
+	}
+}
+
+// recordRiderTrips notes the riders boarding while the doors are open on the way, and getting off at their floor
+func (c *Controller) recordRiderTrips(before map[int]Elevator, after map[int]Elevator) {
+	for index, newElevator := range after {
+		previousElevator := before[index]
+		for _, rider := range previousElevator.riders {
+			if previousElevator.stopping && rider.from == previousElevator.position {
+				c.updateTrip(rider, func(trip *Trip) {
+					trip.PickupTick = c.tick
+				})
+			}
+			if rider.to == newElevator.position && !newElevator.hasRider(rider) {
+				c.updateTrip(rider, func(trip *Trip) {
+					trip.DeliveryTick = c.tick
+				})
+			}
+		}
+	}
+}
+
+func (e Elevator) hasRider(order Order) bool {
+	for _, rider := range e.riders {
+		if rider == order {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElevator_canPickUpOnTheWay(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		order    Order
+		want     bool
+	}{
+		{
+			name:     "same-direction",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8}},
+			order:    Order{from: 3, to: 6},
+			want:     true,
+		},
+		{
+			name:     "moving-empty",
+			elevator: Elevator{position: 3, state: MovingEmptyTo{Floor(9)}, currentOrder: Order{from: 9, to: 0}},
+			order:    Order{from: 3, to: 9},
+			want:     true,
+		},
+		{
+			name:     "opposite-direction",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8}},
+			order:    Order{from: 3, to: 1},
+			want:     false,
+		},
+		{
+			name:     "beyond-the-target",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8}},
+			order:    Order{from: 3, to: 9},
+			want:     false,
+		},
+		{
+			name:     "other-floor",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8}},
+			order:    Order{from: 4, to: 6},
+			want:     false,
+		},
+		{
+			name:     "near-full-load",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8, weight: 520}, ratedLoad: 630},
+			order:    Order{from: 3, to: 6, weight: 75},
+			want:     false,
+		},
+		{
+			name:     "not-served",
+			elevator: Elevator{position: 3, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 0, to: 8}, servedFloors: []Floor{0, 3, 8}},
+			order:    Order{from: 3, to: 6},
+			want:     false,
+		},
+		{
+			name:     "loading",
+			elevator: Elevator{position: 3, state: LoadingAtFloor{Floor(3)}, currentOrder: Order{from: 3, to: 8}},
+			order:    Order{from: 3, to: 6},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevator.canPickUpOnTheWay(tt.order); got != tt.want {
+				t.Errorf("canPickUpOnTheWay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_pickUpOnTheWay(t *testing.T) {
+	tests := []struct {
+		name       string
+		second     Order
+		wantTrips  []Trip
+		wantPicked int
+	}{
+		{
+			name:   "on-the-way",
+			second: Order{from: 3, to: 6},
+			wantTrips: []Trip{
+				{ID: 1, From: 0, To: 8, Elevator: 1, AssignTick: 1, PickupTick: 2, DeliveryTick: 14},
+				{ID: 2, From: 3, To: 6, Elevator: 1, AssignTick: 6, PickupTick: 7, DeliveryTick: 11},
+			},
+			wantPicked: 1,
+		},
+		{
+			name:   "opposite-direction",
+			second: Order{from: 3, to: 1},
+			wantTrips: []Trip{
+				{ID: 1, From: 0, To: 8, Elevator: 1, AssignTick: 1, PickupTick: 2, DeliveryTick: 12},
+				{ID: 2, From: 3, To: 1, Elevator: 1, AssignTick: 12, PickupTick: notYet, DeliveryTick: notYet},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := NewController(0)
+			controller.AddElevator(1)
+			controller.EnableInvariantChecks()
+			controller.PushOrder(0, 8)
+			controller.PushOrder(tt.second.from.toInt(), tt.second.to.toInt())
+			for i := 0; i < 14; i++ {
+				controller.step()
+			}
+
+			trips := controller.Trips()
+			if !reflect.DeepEqual(trips, tt.wantTrips) {
+				t.Errorf("Trips() = %+v, want %+v", trips, tt.wantTrips)
+			}
+			if got := controller.Metrics().PickupsOnTheWay; got != tt.wantPicked {
+				t.Errorf("PickupsOnTheWay = %d, want %d", got, tt.wantPicked)
+			}
+			if violations := controller.Violations(); len(violations) > 0 {
+				t.Errorf("Violations() = %v", violations)
+			}
+		})
+	}
+}
+
+func TestController_pickUpOnTheWay_crossedFloor(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1, WithMotion(Motion{Speed: 2}))
+	controller.PushOrder(0, 8)
+	for i := 0; i < 3; i++ {
+		controller.step()
+	}
+	controller.PushOrder(3, 6)
+
+	// the car goes from floor 0 to floor 2, then to floor 4, without stopping at floor 3 it crossed
+	for i := 0; i < 2; i++ {
+		controller.step()
+	}
+	if elevator := controller.elevators[1]; elevator.position != Floor(4) || len(elevator.riders) > 0 {
+		t.Fatalf("the car should have crossed floor 3 without riders, got %+v", elevator)
+	}
+	if got := controller.Metrics().PickupsOnTheWay; got != 0 {
+		t.Errorf("PickupsOnTheWay = %d, want 0", got)
+	}
+	if want := (Orders{{from: 3, to: 6, id: 2}}); !reflect.DeepEqual(controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = %+v, want %+v", controller.ordersBuffer, want)
+	}
+}
+
+func TestElevator_releaseOrder_keepsRiders(t *testing.T) {
+	elevator := Elevator{index: 1, position: 4, state: MovingEmptyTo{Floor(9)}, currentOrder: Order{from: 9, to: 0, id: 1}, riders: Orders{{from: 3, to: 6, id: 2}, {from: 4, to: 8, id: 3}}}
+
+	got, released := elevator.releaseOrder()
+
+	want := Elevator{index: 1, position: 4, state: TransportingPeopleTo{Floor(8)}, currentOrder: Order{from: 4, to: 8, id: 3}, riders: Orders{{from: 3, to: 6, id: 2}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("releaseOrder() = %+v, want %+v", got, want)
+	}
+	if released != (Order{from: 9, to: 0, id: 1}) {
+		t.Errorf("released order = %v, want [9->0]", released)
+	}
+}
@@ -0,0 +1,150 @@
+package elevator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Scenario describes a simulation run: the elevators, then the orders, faults and fire recalls with the tick they happen at
+type Scenario struct {
+	Elevators    []ScenarioElevator `json:"elevators"`
+	Orders       []ScenarioOrder    `json:"orders"`
+	Faults       []ScenarioFault    `json:"faults"`
+	RandomFaults *ScenarioRandom    `json:"randomFaults"`
+	FireRecalls  []ScenarioRecall   `json:"fireRecalls"`
+	// Cancellations refer to orders by their id, numbered from 1 in the order they are pushed
+	Cancellations []ScenarioCancellation `json:"cancellations"`
+	// MaxExtraWaitTicks enables the energy aware dispatch when set
+	MaxExtraWaitTicks *int `json:"maxExtraWaitTicks"`
+	// Parking is one of lobby, spread or demand, elevators stay where they are when empty
+	Parking string `json:"parking"`
+}
+
+type ScenarioElevator struct {
+	Index        int          `json:"index"`
+	Speed        float64      `json:"speed"`
+	Acceleration float64      `json:"acceleration"`
+	ServedFloors []int        `json:"servedFloors"`
+	Energy       *EnergyModel `json:"energy"`
+	RatedLoad    int          `json:"ratedLoad"`
+}
+
+type ScenarioOrder struct {
+	Tick     int    `json:"tick"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	Priority string `json:"priority"`
+	Weight   int    `json:"weight"`
+}
+
+type ScenarioFault struct {
+	Tick     int    `json:"tick"`
+	Elevator int    `json:"elevator"`
+	Kind     string `json:"kind"`
+	Ticks    int    `json:"ticks"`
+}
+
+type ScenarioRecall struct {
+	Tick  int `json:"tick"`
+	Floor int `json:"floor"`
+	Ticks int `json:"ticks"`
+}
+
+type ScenarioCancellation struct {
+	Tick  int `json:"tick"`
+	Order int `json:"order"`
+}
+
+type ScenarioRandom struct {
+	Seed        int64   `json:"seed"`
+	Probability float64 `json:"probability"`
+}
+
+func LoadScenario(path string) (Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("cannot read scenario file %s : %w", path, err)
+	}
+
+	var scenario Scenario
+	err = json.Unmarshal(content, &scenario)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("invalid scenario file %s : %w", path, err)
+	}
+	return scenario, nil
+}
+
+func (s ScenarioElevator) options() []ElevatorOption {
+	options := []ElevatorOption{}
+	if s.Speed > 0 {
+		options = append(options, WithMotion(Motion{Speed: s.Speed, Acceleration: s.Acceleration}))
+	}
+	if len(s.ServedFloors) > 0 {
+		options = append(options, WithServedFloors(s.ServedFloors...))
+	}
+	if s.Energy != nil {
+		options = append(options, WithEnergyModel(*s.Energy))
+	}
+	if s.RatedLoad > 0 {
+		options = append(options, WithRatedLoad(s.RatedLoad))
+	}
+	return options
+}
+
+// Apply configures the controller with the scenario. Orders at tick 0 are pushed at once, the others are scheduled
+func (s Scenario) Apply(c *Controller) error {
+	for _, elevator := range s.Elevators {
+		if !c.AddElevator(elevator.Index, elevator.options()...) {
+			return fmt.Errorf("elevator n°%d is declared twice in the scenario", elevator.Index)
+		}
+	}
+
+	for _, order := range s.Orders {
+		priority, err := priorityFromString(order.Priority)
+		if err != nil {
+			return err
+		}
+		if order.Tick <= 0 {
+			err = c.PushWeightedOrder(order.From, order.To, priority, order.Weight)
+			if err != nil {
+				return err
+			}
+		} else {
+			c.ScheduleWeightedOrder(order.Tick, order.From, order.To, priority, order.Weight)
+		}
+	}
+
+	for _, fault := range s.Faults {
+		kind, err := faultKindFromString(fault.Kind)
+		if err != nil {
+			return err
+		}
+		c.ScheduleFault(fault.Tick, fault.Elevator, Fault{Kind: kind, Ticks: fault.Ticks})
+	}
+
+	for _, recall := range s.FireRecalls {
+		c.ScheduleFireRecall(recall.Tick, recall.Floor, recall.Ticks)
+	}
+
+	for _, cancellation := range s.Cancellations {
+		c.ScheduleCancellation(cancellation.Tick, cancellation.Order)
+	}
+
+	parkingPolicy, err := ParkingPolicyFromString(s.Parking)
+	if err != nil {
+		return err
+	}
+	if parkingPolicy != nil {
+		c.SetParkingPolicy(parkingPolicy)
+	}
+
+	if s.MaxExtraWaitTicks != nil {
+		c.EnableEnergyAwareDispatch(*s.MaxExtraWaitTicks)
+	}
+
+	if s.RandomFaults != nil {
+		c.EnableRandomFaults(s.RandomFaults.Seed, s.RandomFaults.Probability)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package elevator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	content := `{
+		"elevators": [{"index": 1}, {"index": 2, "speed": 2, "servedFloors": [0, 5, 6, 7]}],
+		"orders": [{"from": 1, "to": 3}, {"tick": 4, "from": 6, "to": 0}],
+		"faults": [{"tick": 2, "elevator": 1, "kind": "stuck", "ticks": 3}],
+		"randomFaults": {"seed": 7, "probability": 0.01},
+		"cancellations": [{"tick": 3, "order": 1}]
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenario, err := LoadScenario(path)
+	if err != nil {
+		t.Fatalf("LoadScenario() unexpected error = %v", err)
+	}
+
+	controller := NewController(0)
+	if err := scenario.Apply(controller); err != nil {
+		t.Fatalf("Apply() unexpected error = %v", err)
+	}
+
+	if !reflect.DeepEqual(controller.ordersBuffer, Orders{Order{from: Floor(1), to: Floor(3), id: 1}}) {
+		t.Errorf("ordersBuffer = %+v, wanted [1->3]", controller.ordersBuffer)
+	}
+	if !reflect.DeepEqual(controller.scheduledOrders, []scheduledOrder{{tick: 4, order: Order{from: Floor(6), to: Floor(0)}}}) {
+		t.Errorf("scheduledOrders = %+v", controller.scheduledOrders)
+	}
+	if !reflect.DeepEqual(controller.scheduledFaults, []scheduledFault{{tick: 2, index: 1, fault: Fault{Kind: StuckBetweenFloors, Ticks: 3}}}) {
+		t.Errorf("scheduledFaults = %+v", controller.scheduledFaults)
+	}
+	if !reflect.DeepEqual(controller.scheduledCancellations, []scheduledCancellation{{tick: 3, id: 1}}) {
+		t.Errorf("scheduledCancellations = %+v", controller.scheduledCancellations)
+	}
+	if controller.elevators[2].motion != (Motion{Speed: 2}) || !controller.elevators[2].serves(Floor(6)) || controller.elevators[2].serves(Floor(3)) {
+		t.Errorf("elevator n°2 = %+v", controller.elevators[2])
+	}
+	if controller.elevators[1].motion != DefaultMotion {
+		t.Errorf("elevator n°1 motion = %+v, wanted default motion", controller.elevators[1].motion)
+	}
+	if controller.randomFaults == nil || controller.randomFaults.probability != 0.01 {
+		t.Errorf("random faults not enabled")
+	}
+}
+
+func TestScenario_Apply_failures(t *testing.T) {
+	tests := []struct {
+		name       string
+		scenario   Scenario
+		failureMsg string
+	}{
+		{
+			name:       "duplicated-elevator",
+			scenario:   Scenario{Elevators: []ScenarioElevator{{Index: 1}, {Index: 1}}},
+			failureMsg: "elevator n°1 is declared twice in the scenario",
+		},
+		{
+			name:       "unknown-fault",
+			scenario:   Scenario{Elevators: []ScenarioElevator{{Index: 1}}, Faults: []ScenarioFault{{Tick: 1, Elevator: 1, Kind: "fire"}}},
+			failureMsg: "unknown fault kind 'fire', expected one of stuck, breakdown, doorJam",
+		},
+		{
+			name: "unservable-order",
+			scenario: Scenario{
+				Elevators: []ScenarioElevator{{Index: 1, ServedFloors: []int{0, 1, 2}}},
+				Orders:    []ScenarioOrder{{From: 1, To: 5}},
+			},
+			failureMsg: "order [1->5] rejected, no elevator serves both floor 1 and floor 5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.scenario.Apply(NewController(0)); err == nil || err.Error() != tt.failureMsg {
+				t.Errorf("Apply() failure message = \n%+v\n, expected = \n%+v\n", err, tt.failureMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package elevator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Site runs several named groups of elevators, each with its own building, fleet and dispatcher, on one shared clock
+type Site struct {
+	groups          map[string]*Controller
+	names           []string
+	pauseTimeInSecs int
+	tick            int
+}
+
+func NewSite(pauseTimeInSecs int) *Site {
+	return &Site{
+		groups:          map[string]*Controller{},
+		pauseTimeInSecs: pauseTimeInSecs,
+	}
+}
+
+// AddGroup creates the controller of a new group, its clock starts at the current tick of the site
+func (s *Site) AddGroup(name string, building Building) (*Controller, error) {
+	if _, ok := s.groups[name]; ok {
+		return nil, fmt.Errorf("group '%s' is declared twice in the site", name)
+	}
+	controller := NewController(0)
+	if err := controller.SetBuilding(building); err != nil {
+		return nil, fmt.Errorf("group '%s': %w", name, err)
+	}
+	controller.tick = s.tick
+	s.groups[name] = controller
+	s.names = append(s.names, name)
+	return controller, nil
+}
+
+func (s *Site) Group(name string) (*Controller, bool) {
+	controller, ok := s.groups[name]
+	return controller, ok
+}
+
+// Groups gives the names of the groups, in the order they were added
+func (s *Site) Groups() []string {
+	return s.names
+}
+
+func (s *Site) PushOrder(group string, from int, to int) error {
+	return s.PushPriorityOrder(group, from, to, NormalPriority)
+}
+
+// PushPriorityOrder routes the order to the controller of the group
+func (s *Site) PushPriorityOrder(group string, from int, to int, priority Priority) error {
+	controller, ok := s.groups[group]
+	if !ok {
+		return fmt.Errorf("unknown group '%s', expected one of %s", group, strings.Join(s.names, ", "))
+	}
+	return controller.PushPriorityOrder(from, to, priority)
+}
+
+// step advances every group by one tick
+func (s *Site) step() error {
+	s.tick++
+	for _, name := range s.names {
+		if err := s.groups[name].step(); err != nil {
+			return fmt.Errorf("group '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Site) isOver() bool {
+	for _, name := range s.names {
+		if !s.groups[name].isOver() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Site) display() string {
+	display := fmt.Sprintf("\n\n\tTick %d", s.tick)
+	for _, name := range s.names {
+		display += fmt.Sprintf("\n\n\tGroup %s:", name)
+		display += s.groups[name].display()
+	}
+	return display
+}
+
+func (s *Site) Run() {
+
+	for true {
+
+		fmt.Println(s.display())
+
+		err := s.step()
+		if err != nil {
+			panic(fmt.Sprintf("%s", err))
+		}
+
+		time.Sleep(time.Duration(s.pauseTimeInSecs) * time.Second)
+
+		if s.isOver() {
+			break
+		}
+	}
+
+	fmt.Printf("\n\n**************** End of Simulation *******************\n\n")
+	fmt.Print(s.Report())
+}
+
+// GroupReport sums up the orders and the metrics of a group, or of the whole site
+type GroupReport struct {
+	Name      string  `json:"name"`
+	Orders    int     `json:"orders"`
+	Delivered int     `json:"delivered"`
+	Rejected  int     `json:"rejected"`
+	Cancelled int     `json:"cancelled"`
+	WaitTicks int     `json:"waitTicks"`
+	RideTicks int     `json:"rideTicks"`
+	Metrics   Metrics `json:"metrics"`
+}
+
+func (r *GroupReport) add(trips []Trip, metrics Metrics) {
+	for _, trip := range trips {
+		r.Orders++
+		switch {
+		case trip.Rejected:
+			r.Rejected++
+		case trip.Cancelled:
+			r.Cancelled++
+		case trip.Delivered():
+			r.Delivered++
+			r.WaitTicks += trip.WaitTicks()
+			r.RideTicks += trip.RideTicks()
+		}
+	}
+	r.Metrics = r.Metrics.add(metrics)
+}
+
+// AverageWaitTicks and AverageRideTicks are computed over the delivered orders
+func (r GroupReport) AverageWaitTicks() float64 {
+	if r.Delivered == 0 {
+		return 0
+	}
+	return float64(r.WaitTicks) / float64(r.Delivered)
+}
+
+func (r GroupReport) AverageRideTicks() float64 {
+	if r.Delivered == 0 {
+		return 0
+	}
+	return float64(r.RideTicks) / float64(r.Delivered)
+}
+
+func (r GroupReport) String() string {
+	return fmt.Sprintf("%s: %d orders, %d delivered, %d rejected, %d cancelled, average wait: %.1f ticks, average ride: %.1f ticks, %s",
+		r.Name, r.Orders, r.Delivered, r.Rejected, r.Cancelled, r.AverageWaitTicks(), r.AverageRideTicks(), r.Metrics)
+}
+
+type SiteReport struct {
+	Tick   int           `json:"tick"`
+	Groups []GroupReport `json:"groups"`
+	Site   GroupReport   `json:"site"`
+}
+
+func (r SiteReport) String() string {
+	report := ""
+	for _, group := range r.Groups {
+		report += fmt.Sprintf("\t%s\n\n", group)
+	}
+	return report + fmt.Sprintf("\t%s\n\n", r.Site)
+}
+
+// Report aggregates the orders and the metrics per group and site-wide
+func (s *Site) Report() SiteReport {
+	report := SiteReport{Tick: s.tick, Groups: []GroupReport{}, Site: GroupReport{Name: "site"}}
+	for _, name := range s.names {
+		group := GroupReport{Name: name}
+		group.add(s.groups[name].Trips(), s.groups[name].Metrics())
+		report.Groups = append(report.Groups, group)
+		report.Site.add(s.groups[name].Trips(), s.groups[name].Metrics())
+	}
+	return report
+}
+
+// SiteGroup is a group of the site file: its name, its building and the scenario of its elevators and orders
+type SiteGroup struct {
+	Name     string    `json:"name"`
+	Building *Building `json:"building"`
+	Scenario
+}
+
+type SiteFile struct {
+	Groups []SiteGroup `json:"groups"`
+}
+
+func LoadSite(path string) (SiteFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SiteFile{}, fmt.Errorf("cannot read site file %s : %w", path, err)
+	}
+
+	var site SiteFile
+	err = json.Unmarshal(content, &site)
+	if err != nil {
+		return SiteFile{}, fmt.Errorf("invalid site file %s : %w", path, err)
+	}
+	return site, nil
+}
+
+// Apply adds a group to the site for each group of the file, the groups without a building get the default one
+func (f SiteFile) Apply(s *Site) error {
+	for _, group := range f.Groups {
+		building := DefaultBuilding
+		if group.Building != nil {
+			building = *group.Building
+		}
+		controller, err := s.AddGroup(group.Name, building)
+		if err != nil {
+			return err
+		}
+		if err := group.Scenario.Apply(controller); err != nil {
+			return fmt.Errorf("group '%s': %w", group.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package elevator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSite_AddGroup(t *testing.T) {
+	site := NewSite(0)
+
+	if _, err := site.AddGroup("north", DefaultBuilding); err != nil {
+		t.Fatalf("AddGroup() unexpected error = %v", err)
+	}
+	if _, err := site.AddGroup("north", DefaultBuilding); err == nil {
+		t.Errorf("a group declared twice should fail")
+	}
+	if _, err := site.AddGroup("tower", Building{MinFloor: 20, MaxFloor: 4}); err == nil {
+		t.Errorf("a building with reversed floors should fail")
+	}
+	if !reflect.DeepEqual(site.Groups(), []string{"north"}) {
+		t.Errorf("Groups() = %v", site.Groups())
+	}
+}
+
+func TestSite_PushOrder(t *testing.T) {
+	site := NewSite(0)
+	north, _ := site.AddGroup("north", DefaultBuilding)
+	south, _ := site.AddGroup("south", Building{MinFloor: 0, MaxFloor: 4})
+
+	if err := site.PushOrder("south", 1, 3); err != nil {
+		t.Errorf("PushOrder() unexpected error = %v", err)
+	}
+	if err := site.PushOrder("south", 1, 7); err == nil {
+		t.Errorf("an order above the south building should be rejected")
+	}
+	if err := site.PushOrder("east", 1, 3); err == nil {
+		t.Errorf("an order for an unknown group should fail")
+	}
+
+	if len(north.ordersBuffer) != 0 || !reflect.DeepEqual(south.ordersBuffer, Orders{{from: 1, to: 3, id: 1}}) {
+		t.Errorf("north buffer = %v, south buffer = %v", north.ordersBuffer, south.ordersBuffer)
+	}
+}
+
+func TestSite_step(t *testing.T) {
+	site := NewSite(0)
+	north, _ := site.AddGroup("north", DefaultBuilding)
+	north.AddElevator(1)
+	site.PushOrder("north", 0, 2)
+	site.step()
+	site.step()
+
+	south, _ := site.AddGroup("south", DefaultBuilding)
+	south.AddElevator(1)
+	site.PushOrder("south", 0, 1)
+
+	for !site.isOver() {
+		site.step()
+	}
+
+	if north.tick != site.tick || south.tick != site.tick {
+		t.Errorf("the groups should share the site clock: site %d, north %d, south %d", site.tick, north.tick, south.tick)
+	}
+	if trip := south.Trips()[0]; trip.PushTick != 2 || !trip.Delivered() {
+		t.Errorf("the south order should be pushed at tick 2 and delivered, got %+v", trip)
+	}
+}
+
+func TestSite_Report(t *testing.T) {
+	site := NewSite(0)
+	north, _ := site.AddGroup("north", DefaultBuilding)
+	north.AddElevator(1)
+	south, _ := site.AddGroup("south", Building{MinFloor: 0, MaxFloor: 4})
+	south.AddElevator(1)
+	site.PushOrder("north", 0, 2)
+	site.PushOrder("north", 3, 9)
+	north.CancelOrder(2)
+	site.PushOrder("south", 0, 1)
+	site.PushOrder("south", 0, 8)
+
+	for !site.isOver() {
+		site.step()
+	}
+	report := site.Report()
+
+	if len(report.Groups) != 2 || report.Groups[0].Name != "north" || report.Groups[1].Name != "south" {
+		t.Fatalf("Report() groups = %+v", report.Groups)
+	}
+	if got := report.Groups[0]; got.Orders != 2 || got.Delivered != 1 || got.Cancelled != 1 || got.Metrics.CancelledOrders != 1 {
+		t.Errorf("north report = %+v", got)
+	}
+	if got := report.Groups[1]; got.Orders != 2 || got.Delivered != 1 || got.Rejected != 1 {
+		t.Errorf("south report = %+v", got)
+	}
+	wantSite := report.Groups[0].WaitTicks + report.Groups[1].WaitTicks
+	if got := report.Site; got.Name != "site" || got.Orders != 4 || got.Delivered != 2 || got.WaitTicks != wantSite ||
+		got.Metrics.EnergyKWh != report.Groups[0].Metrics.EnergyKWh+report.Groups[1].Metrics.EnergyKWh {
+		t.Errorf("site report = %+v", got)
+	}
+}
+
+func TestLoadSite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "site.json")
+	content := `{
+		"groups": [
+			{"name": "north", "elevators": [{"index": 1}], "orders": [{"from": 1, "to": 3}]},
+			{"name": "south", "building": {"minFloor": 2, "maxFloor": 5}, "elevators": [{"index": 1}], "orders": [{"tick": 2, "from": 5, "to": 2}]}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	siteFile, err := LoadSite(path)
+	if err != nil {
+		t.Fatalf("LoadSite() unexpected error = %v", err)
+	}
+	site := NewSite(0)
+	if err := siteFile.Apply(site); err != nil {
+		t.Fatalf("Apply() unexpected error = %v", err)
+	}
+
+	north, _ := site.Group("north")
+	south, _ := site.Group("south")
+	if north.Building() != DefaultBuilding || south.Building() != (Building{MinFloor: 2, MaxFloor: 5}) {
+		t.Errorf("buildings = %v, %v", north.Building(), south.Building())
+	}
+	if !reflect.DeepEqual(north.ordersBuffer, Orders{{from: 1, to: 3, id: 1}}) || len(south.scheduledOrders) != 1 {
+		t.Errorf("north buffer = %v, south scheduled orders = %v", north.ordersBuffer, south.scheduledOrders)
+	}
+	if south.elevators[1].position != Floor(2) {
+		t.Errorf("the south elevator should start at floor 2, got %d", south.elevators[1].position)
+	}
+}
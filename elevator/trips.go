@@ -0,0 +1,128 @@
+package elevator
+
+import "sort"
+
+// notYet is the tick of a trip step that did not happen
+const notYet = -1
+
+// Trip follows an order from the moment it is pushed until people get off the elevator
+type Trip struct {
+	ID            int      `json:"id"`
+	From          int      `json:"from"`
+	To            int      `json:"to"`
+	Priority      Priority `json:"priority"`
+	Weight        int      `json:"weight"`
+	Elevator      int      `json:"elevator"`
+	PushTick      int      `json:"pushTick"`
+	AssignTick    int      `json:"assignTick"`
+	PickupTick    int      `json:"pickupTick"`
+	DeliveryTick  int      `json:"deliveryTick"`
+	Reassignments int      `json:"reassignments"`
+	Rejected      bool     `json:"rejected"`
+	Overdue       bool     `json:"overdue"`
+	Cancelled     bool     `json:"cancelled"`
+}
+
+func (t Trip) Delivered() bool {
+	return t.DeliveryTick != notYet
+}
+
+// WaitTicks is the time people waited for the elevator, RideTicks the time they spent inside
+func (t Trip) WaitTicks() int {
+	return t.PickupTick - t.PushTick
+}
+
+func (t Trip) RideTicks() int {
+	return t.DeliveryTick - t.PickupTick
+}
+
+func (c *Controller) Trips() []Trip {
+	trips := []Trip{}
+	for _, trip := range c.trips {
+		trips = append(trips, trip)
+	}
+	sort.Slice(trips, func(i, j int) bool {
+		return trips[i].ID < trips[j].ID
+	})
+	return trips
+}
+
+func (c *Controller) tripPushed(order Order) {
+	if c.trips == nil {
+		c.trips = map[int]Trip{}
+	}
+	c.trips[order.id] = Trip{
+		ID:           order.id,
+		From:         order.from.toInt(),
+		To:           order.to.toInt(),
+		Priority:     order.priority,
+		Weight:       order.weight,
+		Elevator:     notYet,
+		PushTick:     c.tick,
+		AssignTick:   notYet,
+		PickupTick:   notYet,
+		DeliveryTick: notYet,
+	}
+	c.tripChanged(order.id)
+}
+
+// updateTrip changes the trip of the order, orders which were not pushed to the controller have no trip
+func (c *Controller) updateTrip(order Order, update func(trip *Trip)) {
+	trip, ok := c.trips[order.id]
+	if !ok {
+		return
+	}
+	update(&trip)
+	c.trips[order.id] = trip
+	c.tripChanged(order.id)
+}
+
+// tripChanged notes the trips the exporter has to count again at the end of the tick
+func (c *Controller) tripChanged(id int) {
+	if c.exporter != nil {
+		c.changedTrips = append(c.changedTrips, id)
+	}
+}
+
+func (c *Controller) tripRejected(order Order) {
+	c.updateTrip(order, func(trip *Trip) {
+		trip.Rejected = true
+		trip.Elevator = notYet
+	})
+}
+
+func (c *Controller) tripAssigned(order Order, elevatorIndex int) {
+	c.updateTrip(order, func(trip *Trip) {
+		trip.Elevator = elevatorIndex
+		trip.AssignTick = c.tick
+	})
+}
+
+func (c *Controller) tripRequeued(order Order) {
+	c.updateTrip(order, func(trip *Trip) {
+		trip.Elevator = notYet
+		trip.AssignTick = notYet
+		trip.Reassignments++
+	})
+}
+
+// recordTrips notes the pickups and deliveries of the elevators between two ticks
+func (c *Controller) recordTrips(before map[int]Elevator, after map[int]Elevator) {
+	for index, newElevator := range after {
+		previousElevator := before[index]
+		switch newElevator.state.(type) {
+		case LoadingAtFloor:
+			if _, loading := previousElevator.state.(LoadingAtFloor); !loading {
+				c.updateTrip(newElevator.currentOrder, func(trip *Trip) {
+					trip.PickupTick = c.tick
+				})
+			}
+		case UnloadingAtFloor:
+			if _, unloading := previousElevator.state.(UnloadingAtFloor); !unloading && newElevator.position == newElevator.currentOrder.to {
+				c.updateTrip(newElevator.currentOrder, func(trip *Trip) {
+					trip.DeliveryTick = c.tick
+				})
+			}
+		}
+	}
+}
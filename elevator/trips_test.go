@@ -0,0 +1,52 @@
+package elevator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestController_Trips_delivered(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(0, 2)
+
+	controller.Record(100)
+
+	want := []Trip{{ID: 1, From: 0, To: 2, Elevator: 1, PushTick: 0, AssignTick: 1, PickupTick: 2, DeliveryTick: 6}}
+	if got := controller.Trips(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Trips() = %+v, want %+v", got, want)
+	}
+	if trip := controller.Trips()[0]; trip.WaitTicks() != 2 || trip.RideTicks() != 4 {
+		t.Errorf("wait = %d, ride = %d, want 2 and 4", trip.WaitTicks(), trip.RideTicks())
+	}
+}
+
+func TestController_Trips_requeuedThenRejected(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.PushOrder(5, 2)
+	controller.step()
+
+	if err := controller.SetOutOfService(1); err != nil {
+		t.Fatalf("SetOutOfService() unexpected error = %v", err)
+	}
+	controller.step()
+
+	want := []Trip{{ID: 1, From: 5, To: 2, Elevator: notYet, PushTick: 0, AssignTick: notYet, PickupTick: notYet, DeliveryTick: notYet, Reassignments: 1, Rejected: true}}
+	if got := controller.Trips(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Trips() = %+v, want %+v", got, want)
+	}
+}
+
+func TestController_Trips_scheduledOrder(t *testing.T) {
+	controller := NewController(0)
+	controller.AddElevator(1)
+	controller.ScheduleOrder(3, 1, 0)
+
+	controller.Record(3)
+
+	want := []Trip{{ID: 1, From: 1, To: 0, Elevator: 1, PushTick: 3, AssignTick: 3, PickupTick: notYet, DeliveryTick: notYet}}
+	if got := controller.Trips(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Trips() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,347 @@
+package elevator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	tuiLogLines     = 8
+	tuiMinTickDelay = 125 * time.Millisecond
+	tuiMaxTickDelay = 8 * time.Second
+)
+
+// TUI is a keyboard driven terminal interface on top of the controller
+type TUI struct {
+	controller *Controller
+	tickDelay  time.Duration
+	paused     bool
+	selected   int
+	// orderInput holds the floors typed so far for a new order, nil when no order is being typed
+	orderInput []int
+	log        []string
+	logOffset  int
+	previous   Snapshot
+	quit       bool
+}
+
+func NewTUI(controller *Controller) *TUI {
+	tickDelay := time.Duration(controller.pauseTimeInSecs) * time.Second
+	if tickDelay < tuiMinTickDelay {
+		tickDelay = 4 * tuiMinTickDelay
+	}
+	return &TUI{controller: controller, tickDelay: tickDelay, previous: controller.Snapshot()}
+}
+
+// Run takes over the terminal until the key q is pressed. The terminal is put in raw mode with stty
+func (t *TUI) Run(in *os.File, out io.Writer) error {
+	if !IsTerminal(in) {
+		return fmt.Errorf("the interactive interface needs a terminal")
+	}
+	restore, err := rawMode(in)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	keys := make(chan string)
+	done := make(chan struct{})
+	go readKeys(in, keys, done)
+	defer func() {
+		close(done)
+		// wakes the reader up if the terminal supports deadlines, otherwise it stops at the next key
+		_ = in.SetReadDeadline(time.Now())
+	}()
+
+	fmt.Fprint(out, "\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h\033[H\033[2J")
+
+	// a single ticker keeps the pace whatever keys are pressed in between
+	ticker := time.NewTicker(t.tickDelay)
+	defer ticker.Stop()
+
+	for !t.quit {
+		fmt.Fprint(out, strings.ReplaceAll(t.screen(), "\n", "\r\n"))
+
+		var tick <-chan time.Time
+		if !t.paused {
+			tick = ticker.C
+		}
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			tickDelay := t.tickDelay
+			t.handleKey(key)
+			if t.tickDelay != tickDelay {
+				ticker.Reset(t.tickDelay)
+			}
+		case <-tick:
+			t.step()
+		}
+	}
+	return nil
+}
+
+func rawMode(in *os.File) (func(), error) {
+	stty := func(args ...string) ([]byte, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = in
+		return cmd.Output()
+	}
+
+	state, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the terminal settings : %w", err)
+	}
+	_, err = stty("raw", "-echo")
+	if err != nil {
+		return nil, fmt.Errorf("cannot put the terminal in raw mode : %w", err)
+	}
+	return func() {
+		stty(strings.TrimSpace(string(state)))
+	}, nil
+}
+
+// readKeys sends the keys read from the terminal until it is done, then closes the keys
+func readKeys(in io.Reader, keys chan<- string, done <-chan struct{}) {
+	defer close(keys)
+	buffer := make([]byte, 16)
+	for {
+		n, err := in.Read(buffer)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buffer[:n]) {
+			select {
+			case keys <- key:
+			case <-done:
+				return
+			}
+		}
+		select {
+		case <-done:
+			return
+		default:
+		}
+	}
+}
+
+// parseKeys splits what the terminal sent in keys, arrows are named up, down, left and right
+func parseKeys(input []byte) []string {
+	arrows := map[byte]string{'A': "up", 'B': "down", 'C': "right", 'D': "left"}
+
+	keys := []string{}
+	for i := 0; i < len(input); i++ {
+		if input[i] == '\033' {
+			if i+2 < len(input) && input[i+1] == '[' && arrows[input[i+2]] != "" {
+				keys = append(keys, arrows[input[i+2]])
+				i += 2
+			} else {
+				keys = append(keys, "esc")
+			}
+		} else {
+			keys = append(keys, string(input[i]))
+		}
+	}
+	return keys
+}
+
+func (t *TUI) selectedIndex() (int, bool) {
+	indexes := t.controller.sortedIndexes()
+	if len(indexes) == 0 {
+		return 0, false
+	}
+	if t.selected >= len(indexes) {
+		t.selected = len(indexes) - 1
+	}
+	return indexes[t.selected], true
+}
+
+func (t *TUI) handleKey(key string) {
+	if t.orderInput != nil {
+		t.typeOrder(key)
+		return
+	}
+
+	switch key {
+	case "q", "\003":
+		t.quit = true
+	case " ":
+		t.paused = !t.paused
+	case "s":
+		t.step()
+	case "left", "h":
+		if t.selected > 0 {
+			t.selected--
+		}
+	case "right", "l":
+		if t.selected < len(t.controller.elevators)-1 {
+			t.selected++
+		}
+	case "up", "k":
+		if t.logOffset < len(t.log)-tuiLogLines {
+			t.logOffset++
+		}
+	case "down", "j":
+		if t.logOffset > 0 {
+			t.logOffset--
+		}
+	case "+":
+		if t.tickDelay > tuiMinTickDelay {
+			t.tickDelay /= 2
+		}
+	case "-":
+		if t.tickDelay < tuiMaxTickDelay {
+			t.tickDelay *= 2
+		}
+	case "o":
+		t.orderInput = []int{}
+	case "x":
+		if index, ok := t.selectedIndex(); ok {
+			if err := t.controller.SetOutOfService(index); err != nil {
+				t.addLog(err.Error())
+			} else {
+				t.addLog(fmt.Sprintf("elevator n°%d taken out of service", index))
+			}
+		}
+	}
+}
+
+// typeOrder reads the floor from then the floor to of a new order, esc cancels the order
+func (t *TUI) typeOrder(key string) {
+	if key == "esc" {
+		t.orderInput = nil
+		return
+	}
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return
+	}
+
+	t.orderInput = append(t.orderInput, int(key[0]-'0'))
+	if len(t.orderInput) < 2 {
+		return
+	}
+
+	from, to := t.orderInput[0], t.orderInput[1]
+	t.orderInput = nil
+	if err := t.controller.PushOrder(from, to); err != nil {
+		t.addLog(err.Error())
+	} else {
+		t.addLog(fmt.Sprintf("order %s pushed", Order{from: floorFromInt(from), to: floorFromInt(to)}))
+	}
+}
+
+func (t *TUI) step() {
+	if err := t.controller.step(); err != nil {
+		t.addLog(err.Error())
+		t.paused = true
+	}
+	snapshot := t.controller.Snapshot()
+	t.logTransitions(t.previous, snapshot)
+	t.previous = snapshot
+}
+
+func (t *TUI) logTransitions(before Snapshot, after Snapshot) {
+	previousStates := map[int]ElevatorSnapshot{}
+	for _, elevator := range before.Elevators {
+		previousStates[elevator.Index] = elevator
+	}
+	for _, elevator := range after.Elevators {
+		previous, ok := previousStates[elevator.Index]
+		if ok && elevator.Order != nil && (previous.Order == nil || *previous.Order != *elevator.Order) {
+			t.addLog(fmt.Sprintf("order %s assigned to elevator n°%d", elevator.Order, elevator.Index))
+		}
+		if ok && previous.State != elevator.State {
+			t.addLog(fmt.Sprintf("elevator n°%d %s -> %s at floor %d", elevator.Index, previous.State, elevator.State, elevator.Position))
+		}
+		if ok && previous.Fault == "" && elevator.Fault != "" {
+			t.addLog(fmt.Sprintf("elevator n°%d %s", elevator.Index, elevator.Fault))
+		}
+	}
+}
+
+func (t *TUI) addLog(message string) {
+	t.log = append(t.log, fmt.Sprintf("[tick %d] %s", t.controller.tick, message))
+	if t.logOffset > 0 {
+		// keep the scrolled lines in place
+		t.logOffset++
+	}
+}
+
+func (t *TUI) screen() string {
+	snapshot := t.controller.Snapshot()
+	renderer := LineRenderer{Colours: true}
+	selectedIndex, hasSelection := t.selectedIndex()
+
+	display := "\033[H\033[2J"
+	status := "running"
+	if t.paused {
+		status = "PAUSED"
+	}
+	display += fmt.Sprintf(" tick %d | %d order(s) in queue | %v per tick | %s\n", snapshot.Tick, len(snapshot.OrdersBuffer), t.tickDelay, status)
+	display += fmt.Sprintf(" OrdersBuffer: %v\n", snapshot.OrdersBuffer)
+	display += fmt.Sprintf(" Metrics: %s\n\n", snapshot.Metrics)
+
+	for _, elevator := range snapshot.Elevators {
+		cursor := "  "
+		if hasSelection && elevator.Index == selectedIndex {
+			cursor = "▶ "
+		}
+		display += cursor + renderer.elevatorLine(elevator) + "\n"
+	}
+
+	display += "\n"
+	for _, elevator := range snapshot.Elevators {
+		if hasSelection && elevator.Index == selectedIndex {
+			display += t.details(elevator)
+		}
+	}
+
+	display += fmt.Sprintf("\n Events (%d):\n", len(t.log))
+	end := len(t.log) - t.logOffset
+	start := end - tuiLogLines
+	if start < 0 {
+		start = 0
+	}
+	for _, line := range t.log[start:end] {
+		display += "   " + line + "\n"
+	}
+
+	display += "\n"
+	if t.orderInput != nil {
+		if len(t.orderInput) == 0 {
+			display += " New order, type the floor FROM (esc to cancel): "
+		} else {
+			display += fmt.Sprintf(" New order from floor %d, type the floor TO (esc to cancel): ", t.orderInput[0])
+		}
+	} else {
+		display += " ←/→ select  o order  x out of service  +/- speed  space pause  s step  ↑/↓ scroll events  q quit"
+	}
+	return display
+}
+
+func (t *TUI) details(e ElevatorSnapshot) string {
+	order := "none"
+	if e.Order != nil {
+		order = e.Order.String()
+	}
+	details := fmt.Sprintf(" Elevator n°%d: %s at floor %d towards floor %d, order %s", e.Index, e.State, e.Position, e.Target, order)
+	if e.Boarded {
+		details += ", people on board"
+	} else if e.WaitingPickup {
+		details += ", people waiting"
+	}
+	if e.Fault != "" {
+		details += ", " + e.Fault
+	}
+	if e.OutOfService {
+		details += ", OUT OF SERVICE"
+	}
+	return details + "\n"
+}
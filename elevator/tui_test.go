@@ -0,0 +1,156 @@
+package elevator
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "letters", input: "ox", want: []string{"o", "x"}},
+		{name: "arrows", input: "\033[A\033[D\033[C", want: []string{"up", "left", "right"}},
+		{name: "escape", input: "\033", want: []string{"esc"}},
+		{name: "escape-then-digit", input: "\0333", want: []string{"esc", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestTUI() *TUI {
+	controller := NewController(1)
+	controller.AddElevator(1)
+	controller.AddElevator(2)
+	return NewTUI(controller)
+}
+
+func TestReadKeys_done(t *testing.T) {
+	reader, writer := io.Pipe()
+	keys := make(chan string)
+	done := make(chan struct{})
+	go readKeys(reader, keys, done)
+
+	go writer.Write([]byte("ab"))
+	if key := <-keys; key != "a" {
+		t.Fatalf("first key = %s, want a", key)
+	}
+	close(done)
+
+	// nobody reads the key b anymore, the reader stops and closes the keys
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-keys:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("readKeys() should stop once done")
+		}
+	}
+}
+
+func TestTUI_handleKey_order(t *testing.T) {
+	tui := newTestTUI()
+
+	for _, key := range []string{"o", "3", "7"} {
+		tui.handleKey(key)
+	}
+
+	if want := (Orders{Order{from: Floor(3), to: Floor(7), id: 1}}); !reflect.DeepEqual(tui.controller.ordersBuffer, want) {
+		t.Errorf("ordersBuffer = %v, want %v", tui.controller.ordersBuffer, want)
+	}
+	if tui.orderInput != nil {
+		t.Errorf("orderInput = %v, want nil", tui.orderInput)
+	}
+	if want := []string{"[tick 0] order [3->7] pushed"}; !reflect.DeepEqual(tui.log, want) {
+		t.Errorf("log = %v, want %v", tui.log, want)
+	}
+}
+
+func TestTUI_handleKey_cancelOrder(t *testing.T) {
+	tui := newTestTUI()
+
+	for _, key := range []string{"o", "3", "esc", "7"} {
+		tui.handleKey(key)
+	}
+
+	if len(tui.controller.ordersBuffer) != 0 {
+		t.Errorf("ordersBuffer = %v, want empty", tui.controller.ordersBuffer)
+	}
+}
+
+func TestTUI_handleKey_outOfService(t *testing.T) {
+	tui := newTestTUI()
+
+	tui.handleKey("right")
+	tui.handleKey("x")
+
+	if tui.controller.elevators[1].outOfService || !tui.controller.elevators[2].outOfService {
+		t.Errorf("elevator n°2 should be the only one out of service")
+	}
+}
+
+func TestTUI_handleKey_speedAndPause(t *testing.T) {
+	tui := newTestTUI()
+
+	tui.handleKey("+")
+	tui.handleKey(" ")
+
+	if tui.tickDelay != 500*time.Millisecond {
+		t.Errorf("tickDelay = %v, want 500ms", tui.tickDelay)
+	}
+	if !tui.paused {
+		t.Errorf("paused = false, want true")
+	}
+
+	tui.handleKey("q")
+	if !tui.quit {
+		t.Errorf("quit = false, want true")
+	}
+}
+
+func TestTUI_step_logsTransitions(t *testing.T) {
+	tui := newTestTUI()
+	tui.controller.PushOrder(0, 2)
+
+	tui.step()
+	tui.step()
+
+	if want := []string{"[tick 1] order [0->2] assigned to elevator n°1", "[tick 2] elevator n°1 StopAtFloor -> LoadingAtFloor at floor 0"}; !reflect.DeepEqual(tui.log, want) {
+		t.Errorf("log = %v, want %v", tui.log, want)
+	}
+	if !strings.Contains(tui.screen(), "▶ "+ansiGreen+"1 [0->2](LoadingAtFloor)") {
+		t.Errorf("screen() should show the selected elevator loading:\n%s", tui.screen())
+	}
+}
+
+func TestTUI_scrollLog(t *testing.T) {
+	tui := newTestTUI()
+	for i := 0; i < tuiLogLines+2; i++ {
+		tui.addLog("event")
+	}
+
+	for i := 0; i < 5; i++ {
+		tui.handleKey("up")
+	}
+	if tui.logOffset != 2 {
+		t.Errorf("logOffset = %d, want 2", tui.logOffset)
+	}
+
+	tui.handleKey("down")
+	if tui.logOffset != 1 {
+		t.Errorf("logOffset = %d, want 1", tui.logOffset)
+	}
+}
@@ -0,0 +1,73 @@
+package elevator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VerticalRenderer draws the building with one shaft per elevator, floors from top to bottom
+type VerticalRenderer struct {
+	Colours bool
+}
+
+func (v VerticalRenderer) Render(snapshot Snapshot) string {
+	return renderHeader(snapshot) + v.building(snapshot)
+}
+
+// pictogram is the elevator seen in its shaft, with the same pictograms as the line view
+func (v VerticalRenderer) pictogram(e ElevatorSnapshot) string {
+	if e.Fault != "" {
+		return " ⚠ "
+	}
+	if e.OutOfService && e.State == "StopAtFloor" {
+		return " ✖ "
+	}
+	pictogram, _, _ := linePictograms(e)
+	return pictogram
+}
+
+func (v VerticalRenderer) building(snapshot Snapshot) string {
+	waiting, destinations := snapshot.people()
+
+	display := "\t    "
+	for _, elevator := range snapshot.Elevators {
+		display += fmt.Sprintf(" %-3d", elevator.Index)
+	}
+	display += "\n"
+
+	for floor := snapshot.MaxFloor; floor >= snapshot.MinFloor; floor-- {
+		display += fmt.Sprintf("\t%2d │", floor)
+
+		cells := []string{}
+		for _, elevator := range snapshot.Elevators {
+			if elevator.Position == floor {
+				if v.Colours {
+					cells = append(cells, colourize(v.pictogram(elevator), stateColour(elevator)))
+				} else {
+					cells = append(cells, v.pictogram(elevator))
+				}
+			} else {
+				cells = append(cells, " · ")
+			}
+		}
+		display += strings.Join(cells, " ") + "│"
+
+		if orders, ok := waiting[floor]; ok {
+			display += fmt.Sprintf(" %d☹%d %s", floor, floor, joinOrders(orders))
+		}
+		if orders, ok := destinations[floor]; ok {
+			display += fmt.Sprintf(" ❲%d❳ %s", floor, joinOrders(orders))
+		}
+		display += "\n"
+	}
+
+	return display
+}
+
+func joinOrders(orders []OrderSnapshot) string {
+	ordersDisplay := []string{}
+	for _, order := range orders {
+		ordersDisplay = append(ordersDisplay, order.String())
+	}
+	return strings.Join(ordersDisplay, " ")
+}
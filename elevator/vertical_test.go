@@ -0,0 +1,75 @@
+package elevator
+
+import "testing"
+
+func TestVerticalRenderer_pictogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		elevator Elevator
+		want     string
+	}{
+		{
+			name:     "stopped",
+			elevator: Elevator{index: 1, position: 3, state: StopAtFloor{Floor(3)}},
+			want:     "⎣3⎦",
+		},
+		{
+			name:     "moving-empty-down",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(1), to: Floor(4)}, position: 3, state: MovingEmptyTo{Floor(1)}},
+			want:     "⟨⋅|",
+		},
+		{
+			name:     "transporting-up",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(1), to: Floor(4)}, position: 2, state: TransportingPeopleTo{Floor(4)}},
+			want:     "|☺⟩",
+		},
+		{
+			name:     "loading",
+			elevator: Elevator{index: 1, currentOrder: Order{from: Floor(1), to: Floor(4)}, position: 1, state: LoadingAtFloor{Floor(1)}},
+			want:     "↑1↑",
+		},
+		{
+			name:     "faulty",
+			elevator: Elevator{index: 1, position: 2, state: TransportingPeopleTo{Floor(4)}, fault: Fault{Kind: Breakdown}},
+			want:     " ⚠ ",
+		},
+		{
+			name:     "out-of-service",
+			elevator: Elevator{index: 1, position: 2, state: StopAtFloor{Floor(2)}, outOfService: true},
+			want:     " ✖ ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (VerticalRenderer{}).pictogram(tt.elevator.snapshot()); got != tt.want {
+				t.Errorf("pictogram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerticalRenderer_building(t *testing.T) {
+	controller := Controller{
+		elevators: map[int]Elevator{
+			1: {index: 1, currentOrder: Order{from: Floor(1), to: Floor(4)}, position: 2, state: TransportingPeopleTo{Floor(4)}},
+			2: {index: 2, currentOrder: Order{from: Floor(8), to: Floor(0)}, position: 6, state: MovingEmptyTo{Floor(8)}},
+		},
+		ordersBuffer: Orders{Order{from: Floor(8), to: Floor(5)}},
+	}
+
+	want := "\t     1   2  \n" +
+		"\t 9 │ ·   · │\n" +
+		"\t 8 │ ·   · │ 8☹8 [8->5] [8->0]\n" +
+		"\t 7 │ ·   · │\n" +
+		"\t 6 │ ·  |⋅⟩│\n" +
+		"\t 5 │ ·   · │ ❲5❳ [8->5]\n" +
+		"\t 4 │ ·   · │ ❲4❳ [1->4]\n" +
+		"\t 3 │ ·   · │\n" +
+		"\t 2 │|☺⟩  · │\n" +
+		"\t 1 │ ·   · │\n" +
+		"\t 0 │ ·   · │ ❲0❳ [8->0]\n"
+
+	if got := (VerticalRenderer{}).building(controller.Snapshot()); got != want {
+		t.Errorf("building() = \n%v\n, want \n%v\n", got, want)
+	}
+}
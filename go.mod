@@ -1,8 +1,8 @@
 module code_challenge_elevator
 
-go 1.19
+go 1.21
 
 require (
-	github.com/mariomac/gostream v0.8.1 // indirect
-	golang.org/x/exp v0.0.0-20221227203929-1b447090c38c // indirect
+	github.com/mariomac/gostream v0.8.1
+	golang.org/x/exp v0.0.0-20221227203929-1b447090c38c
 )
@@ -4,6 +4,10 @@ import (
 	"code_challenge_elevator/elevator"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
 	"time"
 )
 
@@ -11,8 +15,41 @@ func main() {
 
 	pauseTimeInSecsPtr := flag.Int("pauseTimeInSecs", 2, "Pause time in seconds between 2 states transition")
 	skipPausePtr := flag.Bool("skipPause", false, "Skip the initial pause to read pictograms")
+	sitePtr := flag.String("site", "", "Path to a JSON site file running several groups of elevators on one clock, replacing the default scenario")
+	scenarioPtr := flag.String("scenario", "", "Path to a JSON scenario file, replacing the default scenario")
+	randomFaultsSeedPtr := flag.Int64("randomFaultsSeed", 0, "Seed of the random faults, to replay the same faults")
+	energyAwarePtr := flag.Bool("energyAware", false, "Dispatch orders to the elevator needing the least energy, within the allowed extra wait")
+	maxExtraWaitTicksPtr := flag.Int("maxExtraWaitTicks", 2, "Extra ticks people may wait for an elevator needing less energy, with -energyAware")
+	agingTicksPtr := flag.Int("agingTicks", 0, "Raise the dispatch priority of a waiting order by one level every agingTicks, 0 to disable aging")
+	maxWaitTicksPtr := flag.Int("maxWaitTicks", 0, "Force the dispatch of an order waiting more than maxWaitTicks and count it as overdue, 0 to disable the bound")
+	reassignThresholdPtr := flag.Int("reassignThreshold", 0, "Move an order not picked up yet to a free elevator arriving more than reassignThreshold ticks earlier, 0 to disable reassignments")
+	viewPtr := flag.String("view", "line", "Display of the elevators: line (one line per elevator) vertical (one row per floor, one column per shaft) or json (one snapshot per tick)")
+	ansiPtr := flag.Bool("ansi", false, "Redraw the elevators in place with colours, when the output is a terminal")
+	tuiPtr := flag.Bool("tui", false, "Drive the simulation from the keyboard in a full screen interface")
+	gifPtr := flag.String("gif", "", "Run the simulation without display and save it as an animated GIF at this path")
+	reportPtr := flag.String("report", "", "Run the simulation without display and save an HTML report at this path")
+	ordersCSVPtr := flag.String("ordersCSV", "", "Save one row per order in a CSV file at this path at the end of the simulation")
+	elevatorsCSVPtr := flag.String("elevatorsCSV", "", "Save one row per elevator and per tick in a CSV file at this path at the end of the simulation")
+	metricsAddrPtr := flag.String("metricsAddr", "", "Serve the metrics in the Prometheus format at http://<address>/metrics, for example :9090")
+	logLevelPtr := flag.String("logLevel", "", "Log the dispatch decisions (info) and the transitions of the elevators (debug) on the error output, no log by default")
+	logFormatPtr := flag.String("logFormat", "text", "Format of the logs: text or json")
+	checkInvariantsPtr := flag.Bool("checkInvariants", false, "Verify the invariants of the elevators after every tick and report the violations at the end")
+	maxTicksPtr := flag.Int("maxTicks", 500, "Maximum number of ticks recorded with -gif or -report")
+	parkingPtr := flag.String("parking", "", "Where empty elevators wait for the next order: lobby, spread or demand. By default they stay where they are")
+	randomFaultsProbabilityPtr := flag.Float64("randomFaultsProbability", 0, "Probability for each elevator to fail at each tick, 0 to disable random faults")
 	flag.Parse()
 
+	if *sitePtr != "" {
+		// the displays and the exports follow a single controller, not the groups of a site
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "ansi", "tui", "gif", "report", "ordersCSV", "elevatorsCSV", "metricsAddr":
+				fmt.Fprintf(os.Stderr, "the flag -%s cannot be used with -site\n", f.Name)
+				os.Exit(2)
+			}
+		})
+	}
+
 	banner := `
  ██████╗  ██████╗      ██████╗ ██████╗ ██████╗ ███████╗     ██████╗██╗  ██╗ █████╗ ██╗     ██╗     ███████╗███╗   ██╗ ██████╗ ███████╗
 ██╔════╝ ██╔═══██╗    ██╔════╝██╔═══██╗██╔══██╗██╔════╝    ██╔════╝██║  ██║██╔══██╗██║     ██║     ██╔════╝████╗  ██║██╔════╝ ██╔════╝
@@ -29,10 +66,6 @@ func main() {
 ╚══════╝╚══════╝╚══════╝  ╚═══╝  ╚═╝  ╚═╝   ╚═╝    ╚═════╝ ╚═╝  ╚═╝                                                                   
                                                                                                                                       
 	`
-	fmt.Println(banner)
-
-	fmt.Printf("\n\tTime between 2 states transitions set to %d seconds\n\n", *pauseTimeInSecsPtr)
-
 	legends := `
 	Pictograms:
 	
@@ -46,6 +79,14 @@ func main() {
 	|☺⟩ : elevator TRANSPORTING people moving UP
 	⟨☺| : elevator TRANSPORTING people moving DOWN
 	x->y: an ORDER to take people from floor 'x' to floor 'y'
+	{x->y}: a VIP ORDER, dispatched before normal orders
+	<x->y>: a MEDICAL EMERGENCY ORDER, dispatched first, it can take an elevator moving empty to a less urgent order
+	✖   : elevator OUT OF SERVICE, it delivers people on board then parks
+	⚠   : elevator FAULT (stuck between floors, broken down or door jammed)
+	|!⟩ : elevator RECALLED, moving UP to the recall floor
+	⟨!| : elevator RECALLED, moving DOWN to the recall floor
+	⚑x⚑ : fire RECALL floor 'x'
+	⌂x⌂ : PARKING floor 'x' where an empty elevator goes to wait for the next order
 	
 	Display system: 
 	
@@ -55,23 +96,196 @@ func main() {
 
 	Pausing 15 seconds to let you read the pictograms and understand the display system ....
 `
-	fmt.Print(legends)
+	headless := *gifPtr != "" || *reportPtr != ""
+	if !headless {
+		fmt.Println(banner)
+
+		fmt.Printf("\n\tTime between 2 states transitions set to %d seconds\n\n", *pauseTimeInSecsPtr)
+
+		fmt.Print(legends)
+
+		if !*skipPausePtr {
+			time.Sleep(15 * time.Second)
+		}
+	}
+
+	renderer, err := elevator.RendererFromString(*viewPtr)
+	if err != nil {
+		panic(err)
+	}
+
+	var logger *slog.Logger
+	if *logLevelPtr != "" {
+		logger, err = elevator.NewLogger(os.Stderr, *logLevelPtr, *logFormatPtr)
+		if err != nil {
+			panic(err)
+		}
+	}
 
-	if !*skipPausePtr {
-		time.Sleep(15 * time.Second)
+	// configure applies the dispatch flags to a controller, after its scenario
+	configure := func(controller *elevator.Controller) {
+		if *parkingPtr != "" {
+			parkingPolicy, err := elevator.ParkingPolicyFromString(*parkingPtr)
+			if err != nil {
+				panic(err)
+			}
+			controller.SetParkingPolicy(parkingPolicy)
+		}
+
+		if *energyAwarePtr {
+			controller.EnableEnergyAwareDispatch(*maxExtraWaitTicksPtr)
+		}
+
+		controller.SetOrderAging(*agingTicksPtr, *maxWaitTicksPtr)
+		controller.SetReassignmentThreshold(*reassignThresholdPtr)
+
+		if *randomFaultsProbabilityPtr > 0 {
+			controller.EnableRandomFaults(*randomFaultsSeedPtr, *randomFaultsProbabilityPtr)
+		}
+
+		if *checkInvariantsPtr {
+			controller.EnableInvariantChecks()
+		}
+	}
+
+	if *sitePtr != "" {
+		runSite(*sitePtr, *pauseTimeInSecsPtr, renderer, logger, configure)
+		return
 	}
 
 	controller := elevator.NewController(*pauseTimeInSecsPtr)
+	controller.SetRenderer(renderer)
+	if logger != nil {
+		controller.SetLogger(logger)
+	}
+	if *ansiPtr && !controller.EnableAnsiScreen(os.Stdout) {
+		fmt.Println("\tThe output is not a terminal, the elevators are printed tick after tick")
+	}
+
+	if *scenarioPtr != "" {
+		scenario, err := elevator.LoadScenario(*scenarioPtr)
+		if err != nil {
+			panic(err)
+		}
+		err = scenario.Apply(controller)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		controller.AddElevator(1)
+		controller.AddElevator(2)
 
-	controller.AddElevator(1)
-	controller.AddElevator(2)
+		controller.PushOrder(1, 3)
+		controller.PushOrder(5, 2)
+		controller.PushOrder(0, 2)
+		controller.PushOrder(3, 6)
+		controller.PushOrder(4, 0)
+	}
 
-	controller.PushOrder(1, 3)
-	controller.PushOrder(5, 2)
-	controller.PushOrder(0, 2)
-	controller.PushOrder(3, 6)
-	controller.PushOrder(4, 0)
+	configure(controller)
 
-	controller.Run()
+	if *ordersCSVPtr != "" || *elevatorsCSVPtr != "" {
+		controller.EnableRecording()
+	}
 
+	if *metricsAddrPtr != "" {
+		exporter := elevator.NewPrometheusExporter()
+		controller.SetPrometheusExporter(exporter)
+		http.Handle("/metrics", exporter)
+		go func() {
+			panic(http.ListenAndServe(*metricsAddrPtr, nil))
+		}()
+	}
+
+	if headless {
+		controller.Record(*maxTicksPtr)
+	} else if *tuiPtr {
+		err = elevator.NewTUI(controller).Run(os.Stdin, os.Stdout)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		controller.Run()
+	}
+
+	if headless && *checkInvariantsPtr {
+		fmt.Printf("\tInvariant violations: %d\n", len(controller.Violations()))
+		for _, violation := range controller.Violations() {
+			fmt.Printf("\t  %s\n", violation)
+		}
+	}
+
+	snapshots := controller.Snapshots()
+	if *gifPtr != "" {
+		writeFile(*gifPtr, func(out io.Writer) error {
+			return elevator.EncodeGIF(out, snapshots)
+		})
+		fmt.Printf("\t%d ticks saved to %s\n", len(snapshots), *gifPtr)
+	}
+	if *reportPtr != "" {
+		writeFile(*reportPtr, func(out io.Writer) error {
+			return elevator.WriteHTMLReport(out, snapshots, controller.Trips())
+		})
+		fmt.Printf("\tReport of %d ticks saved to %s\n", len(snapshots), *reportPtr)
+	}
+	if *ordersCSVPtr != "" {
+		writeFile(*ordersCSVPtr, func(out io.Writer) error {
+			return elevator.WriteOrdersCSV(out, controller.Trips())
+		})
+		fmt.Printf("\tOrders saved to %s\n", *ordersCSVPtr)
+	}
+	if *elevatorsCSVPtr != "" {
+		writeFile(*elevatorsCSVPtr, func(out io.Writer) error {
+			return elevator.WriteElevatorsCSV(out, snapshots)
+		})
+		fmt.Printf("\tElevators of %d ticks saved to %s\n", len(snapshots), *elevatorsCSVPtr)
+	}
+
+	if *metricsAddrPtr != "" {
+		fmt.Printf("\tThe simulation is over, the last metrics are still served at %s/metrics\n", *metricsAddrPtr)
+		select {}
+	}
+}
+
+func writeFile(path string, write func(out io.Writer) error) {
+	file, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	err = write(file)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// runSite runs every group of the site file on one clock, with the dispatch flags applied to each group,
+// then prints the report of each group and of the whole site
+func runSite(path string, pauseTimeInSecs int, renderer elevator.Renderer, logger *slog.Logger, configure func(controller *elevator.Controller)) {
+	siteFile, err := elevator.LoadSite(path)
+	if err != nil {
+		panic(err)
+	}
+	site := elevator.NewSite(pauseTimeInSecs)
+	err = siteFile.Apply(site)
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range site.Groups() {
+		group, _ := site.Group(name)
+		group.SetRenderer(renderer)
+		if logger != nil {
+			group.SetLogger(logger.With("group", name))
+		}
+		configure(group)
+	}
+	site.Run()
+
+	for _, name := range site.Groups() {
+		group, _ := site.Group(name)
+		for _, violation := range group.Violations() {
+			fmt.Printf("\tInvariant violation in group %s: %s\n", name, violation)
+		}
+	}
 }